@@ -0,0 +1,113 @@
+package parole
+
+import "sort"
+
+// Parola del dizionario con il suo grado: il numero di parole simili
+type WordDegree struct {
+	Word   string
+	Degree int
+}
+
+// Diametro di un gruppo del dizionario
+type GroupDiameter struct {
+	Size     int      // numero di parole del gruppo
+	Diameter int      // massima lunghezza di una catena minima tra due parole del gruppo
+	Chain    []string // una catena minima di lunghezza pari al diametro
+}
+
+// Se w è nel dizionario d restituisce il numero di parole simili a w e true, altrimenti 0 e false
+func (d *Dictionary) Degree(w string) (int, bool) {
+	if !d.HasWord(w) {
+		return 0, false
+	}
+	return len(d.vicini(w)), true
+}
+
+// Restituisce le n parole di grado massimo, per grado decrescente e a parità di grado
+// in ordine alfabetico. Se n non è positivo le restituisce tutte
+func (d *Dictionary) Hubs(n int) []WordDegree {
+	gradi := make([]WordDegree, 0, len(d.parole))
+	for w := range d.parole {
+		gradi = append(gradi, WordDegree{Word: w, Degree: len(d.vicini(w))})
+	}
+	sort.Slice(gradi, func(i, j int) bool {
+		if gradi[i].Degree != gradi[j].Degree {
+			return gradi[i].Degree > gradi[j].Degree
+		}
+		return gradi[i].Word < gradi[j].Word
+	})
+	if n > 0 && n < len(gradi) {
+		gradi = gradi[:n]
+	}
+	return gradi
+}
+
+// Restituisce le parole del dizionario d senza parole simili, in ordine alfabetico
+func (d *Dictionary) Isolated() []string {
+	var isolate []string
+	for w := range d.parole {
+		if len(d.vicini(w)) == 0 {
+			isolate = append(isolate, w)
+		}
+	}
+	sort.Strings(isolate)
+	return isolate
+}
+
+// Se w è nel dizionario d restituisce la sua eccentricità, la massima lunghezza di una
+// catena minima tra w e le parole del suo gruppo, e true. Altrimenti restituisce 0 e false
+func (d *Dictionary) Eccentricity(w string) (int, bool) {
+	if !d.HasWord(w) {
+		return 0, false
+	}
+	eccentricita, _ := d.piuLontana(w)
+	return eccentricita, true
+}
+
+// Restituisce il diametro di ogni gruppo del dizionario d, nell'ordine di Groups.
+// La catena d'esempio collega la prima parola in ordine alfabetico di eccentricità pari
+// al diametro con la prima delle parole più lontane, ed è la prima in ordine lessicografico
+func (d *Dictionary) Diameters() []GroupDiameter {
+	gruppi := d.Groups()
+	diametri := make([]GroupDiameter, len(gruppi))
+	for i, gruppo := range gruppi {
+		x, y, diametro := gruppo[0], gruppo[0], 0
+		for _, w := range gruppo {
+			if eccentricita, v := d.piuLontana(w); eccentricita > diametro {
+				x, y, diametro = w, v, eccentricita
+			}
+		}
+		catena := []string{x}
+		if x != y {
+			catene, _ := d.AllShortestChains(x, y, 1)
+			catena = catene[0]
+		}
+		diametri[i] = GroupDiameter{Size: len(gruppo), Diameter: diametro, Chain: catena}
+	}
+	return diametri
+}
+
+// Restituisce l'eccentricità di w e la prima in ordine alfabetico delle parole
+// a quella distanza da w, con una visita in ampiezza per livelli
+func (d *Dictionary) piuLontana(w string) (int, string) {
+	visitata := map[string]bool{w: true}
+	livello := []string{w}
+	distanza := 0
+	for {
+		var prossimo []string
+		for _, u := range livello {
+			for _, v := range d.vicini(u) {
+				if !visitata[v] {
+					visitata[v] = true
+					prossimo = append(prossimo, v)
+				}
+			}
+		}
+		if len(prossimo) == 0 {
+			sort.Strings(livello)
+			return distanza, livello[0]
+		}
+		livello = prossimo
+		distanza++
+	}
+}
@@ -0,0 +1,46 @@
+package parole
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalisi(t *testing.T) {
+	d := nuovoDizionario(t, "bba", "aa", "aba", "aaa", "cca", "xy", "xyz")
+
+	if grado, ok := d.Degree("aba"); !ok || grado != 3 {
+		t.Errorf("Degree(aba) = %d, %v, atteso 3", grado, ok)
+	}
+	if _, ok := d.Degree("zz"); ok {
+		t.Errorf("Degree(zz) ha trovato la parola")
+	}
+	atteso := []WordDegree{{"aba", 3}, {"aa", 2}}
+	if got := d.Hubs(2); !reflect.DeepEqual(got, atteso) {
+		t.Errorf("Hubs(2) = %v, atteso %v", got, atteso)
+	}
+	if got := d.Hubs(0); len(got) != 7 {
+		t.Errorf("Hubs(0) = %v, attese tutte le parole", got)
+	}
+	if got, want := d.Isolated(), []string{"cca"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Isolated() = %v, atteso %v", got, want)
+	}
+
+	casi := []struct {
+		w      string
+		atteso int
+	}{{"aa", 2}, {"aba", 1}, {"bba", 2}, {"cca", 0}}
+	for _, c := range casi {
+		if got, ok := d.Eccentricity(c.w); !ok || got != c.atteso {
+			t.Errorf("Eccentricity(%s) = %d, %v, atteso %d", c.w, got, ok, c.atteso)
+		}
+	}
+
+	diametri := []GroupDiameter{
+		{Size: 4, Diameter: 2, Chain: []string{"aa", "aba", "bba"}},
+		{Size: 2, Diameter: 1, Chain: []string{"xy", "xyz"}},
+		{Size: 1, Diameter: 0, Chain: []string{"cca"}},
+	}
+	if got := d.Diameters(); !reflect.DeepEqual(got, diametri) {
+		t.Errorf("Diameters() = %v, atteso %v", got, diametri)
+	}
+}
@@ -0,0 +1,348 @@
+package parole
+
+import "container/heap"
+
+// Strategia di ricerca di una catena di lunghezza minima
+type Strategy int
+
+const (
+	StrategyBidirectional Strategy = iota // visita in ampiezza contemporanea da x e da y (predefinita)
+	StrategyBFS                           // visita in ampiezza da x
+	StrategyAStar                         // ricerca A* guidata dalla distanza di editing da y
+)
+
+// Opzioni della ricerca di una catena
+type ChainOptions struct {
+	Strategy Strategy
+	Step     Step     // relazione tra parole consecutive della catena (predefinita StepEdit)
+	Avoid    []string // parole che la catena non può contenere
+	Via      []string // parole da attraversare, nell'ordine dato, tra x e y
+}
+
+// Risultato della ricerca di una catena
+type ChainResult struct {
+	Chain    []string // catena da x a y
+	Expanded int      // numero di parole di cui sono stati enumerati i vicini
+}
+
+// Se esiste, restituisce una catena di lunghezza minima tra le parole x e y del dizionario d.
+// Restituisce ErrNoChain se x o y non sono nel dizionario o se la catena non esiste
+func (d *Dictionary) Chain(x, y string) ([]string, error) {
+	risultato, err := d.ChainWith(x, y, ChainOptions{})
+	return risultato.Chain, err
+}
+
+// Come Chain, con la strategia di ricerca, la relazione di passo e i vincoli indicati nelle opzioni.
+// Con delle tappe obbligatorie la catena non ripete parole: ogni tratto tra tappe consecutive
+// evita le parole dei tratti precedenti e le tappe successive. Si prova prima il tratto di
+// lunghezza minima trovato dalla strategia; se così la catena non si completa si provano in
+// profondità gli altri tratti senza ripetizioni, quindi ErrNoChain è restituito solo se nessuna
+// catena senza ripetizioni attraversa le tappe nell'ordine dato. Nel caso peggiore questa
+// ricerca richiede tempo esponenziale.
+// Il risultato riporta anche il numero di parole espanse durante la ricerca
+func (d *Dictionary) ChainWith(x, y string, opzioni ChainOptions) (ChainResult, error) {
+	vietata := make(map[string]bool, len(opzioni.Avoid))
+	for _, w := range opzioni.Avoid {
+		vietata[w] = true
+	}
+	// Parole della catena costruita finora, tranne l'ultima da cui parte il tratto corrente,
+	// e numero di occorrenze delle tappe dopo la destinazione del tratto corrente
+	attraversata := make(map[string]bool)
+	successive := make(map[string]int)
+	// Parole in relazione di passo con w che la catena può attraversare
+	successori := func(w string) []string {
+		var ammesse []string
+		for _, v := range opzioni.Step.neighbors(d, w) {
+			if !vietata[v] && !attraversata[v] && successive[v] == 0 {
+				ammesse = append(ammesse, v)
+			}
+		}
+		return ammesse
+	}
+	// Limite inferiore al numero di passi tra w e y
+	stima := func(w, y string) int {
+		return opzioni.Step.stima(d.simboli(w), d.simboli(y))
+	}
+
+	// Tappe consecutive uguali si attraversano una sola volta
+	tappe := []string{x}
+	for _, w := range append(append([]string(nil), opzioni.Via...), y) {
+		if w != tappe[len(tappe)-1] {
+			tappe = append(tappe, w)
+		}
+	}
+	for _, w := range tappe {
+		if !d.HasWord(w) || vietata[w] {
+			return ChainResult{}, ErrNoChain // Parole non presenti nel dizionario o vietate
+		}
+	}
+	for _, w := range tappe[1:] {
+		successive[w]++
+	}
+
+	risultato := ChainResult{Chain: []string{x}}
+	// Cerca con la strategia scelta un tratto di lunghezza minima da u a v
+	trattoMinimo := func(u, v string) ([]string, bool) {
+		var tratto ChainResult
+		var err error
+		switch opzioni.Strategy {
+		case StrategyBFS:
+			tratto, err = catenaBFS(u, v, successori)
+		case StrategyAStar:
+			tratto, err = catenaAStar(u, v, successori, stima)
+		default:
+			tratto, err = catenaBidirezionale(u, v, successori)
+		}
+		risultato.Expanded += tratto.Expanded
+		return tratto.Chain, err == nil
+	}
+	// Aggiunge alla catena la parola v, successiva all'ultima, e ritira l'aggiunta
+	aggiungi := func(v string) {
+		attraversata[risultato.Chain[len(risultato.Chain)-1]] = true
+		risultato.Chain = append(risultato.Chain, v)
+	}
+	ritira := func() {
+		risultato.Chain = risultato.Chain[:len(risultato.Chain)-1]
+		delete(attraversata, risultato.Chain[len(risultato.Chain)-1])
+	}
+
+	// Completa la catena, che termina con la tappa i; restituisce false se non è possibile
+	var completa func(i int) bool
+	// Prolunga in profondità il tratto verso la tappa i+1, che inizia in posizione inizio
+	// della catena, scartando il tratto minimo già provato
+	var prolunga func(i, inizio int, minimo []string) bool
+
+	completa = func(i int) bool {
+		if i+1 == len(tappe) {
+			return true
+		}
+		destinazione := tappe[i+1]
+		successive[destinazione]--
+		defer func() { successive[destinazione]++ }()
+		if attraversata[destinazione] || successive[destinazione] > 0 {
+			// La destinazione del tratto è già nella catena o deve comparire più avanti
+			return false
+		}
+
+		minimo, ok := trattoMinimo(tappe[i], destinazione)
+		if !ok {
+			return false
+		}
+		for _, v := range minimo[1:] {
+			aggiungi(v)
+		}
+		if completa(i + 1) {
+			return true
+		}
+		for range minimo[1:] {
+			ritira()
+		}
+		if !tappeRaggiungibili(tappe[i+1:], successive, trattoMinimo) {
+			// Qualche tratto successivo non esiste nemmeno ora: nessuna scelta di questo tratto lo rende possibile
+			return false
+		}
+		return prolunga(i, len(risultato.Chain)-1, minimo)
+	}
+
+	prolunga = func(i, inizio int, minimo []string) bool {
+		risultato.Expanded++
+		for _, v := range successori(risultato.Chain[len(risultato.Chain)-1]) {
+			aggiungi(v)
+			if v == tappe[i+1] {
+				if chiaveCatena(risultato.Chain[inizio:]) != chiaveCatena(minimo) && completa(i+1) {
+					return true
+				}
+			} else if prolunga(i, inizio, minimo) {
+				return true
+			}
+			ritira()
+		}
+		return false
+	}
+
+	if !completa(0) {
+		return ChainResult{Expanded: risultato.Expanded}, ErrNoChain
+	}
+	return risultato, nil
+}
+
+// Restituisce true se tra ciascuna coppia di tappe consecutive esiste un tratto secondo
+// trattoMinimo, escludendo ogni volta le tappe che seguono la destinazione del tratto.
+// successive conta le occorrenze delle tappe dopo la prima ed è ripristinato al ritorno
+func tappeRaggiungibili(tappe []string, successive map[string]int, trattoMinimo func(u, v string) ([]string, bool)) bool {
+	for i := 0; i+1 < len(tappe); i++ {
+		successive[tappe[i+1]]--
+		defer func(w string) { successive[w]++ }(tappe[i+1])
+		if _, ok := trattoMinimo(tappe[i], tappe[i+1]); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Cerca la catena tra x e y con una visita in ampiezza a partire da x,
+// passando da ogni parola alle parole restituite da successori
+func catenaBFS(x, y string, successori func(string) []string) (ChainResult, error) {
+	var risultato ChainResult
+
+	// Coda per la BFS
+	queue := []string{x}
+
+	// Mappa per tracciare i predecessori e ricostruire il percorso
+	predecessore := make(map[string]string)
+
+	// Insieme delle parole visitate
+	visitato := make(map[string]bool)
+	visitato[x] = true
+
+	for len(queue) > 0 {
+		parolaCorrente := queue[0]
+		queue = queue[1:]
+		risultato.Expanded++
+
+		// Scorro le parole simili
+		for _, parolaVicino := range successori(parolaCorrente) {
+			// Se già visitata, skippo/continuo
+			if visitato[parolaVicino] {
+				continue
+			}
+			// Salvo predecessore
+			predecessore[parolaVicino] = parolaCorrente
+			// Se arrivo alla destinazione ricostruisco il percorso
+			if parolaVicino == y {
+				risultato.Chain = ricostruisciCatena(predecessore, x, y)
+				return risultato, nil
+			}
+			// Altrimenti aggiungo alla coda e segno come visitata
+			queue = append(queue, parolaVicino)
+			visitato[parolaVicino] = true
+		}
+	}
+
+	// Se esco dal ciclo senza aver trovato y la catena non esiste
+	return risultato, ErrNoChain
+}
+
+// Cerca la catena tra x e y con l'algoritmo A*, passando da ogni parola alle parole
+// restituite da successori. stima(w, y) è un limite inferiore consistente al numero di
+// passi tra w e y: ogni parola è espansa al più una volta e la catena è di lunghezza minima.
+// A parità di lunghezza stimata si espandono prima le parole più lontane da x
+func catenaAStar(x, y string, successori func(string) []string, stima func(w, y string) int) (ChainResult, error) {
+	var risultato ChainResult
+	distanza := map[string]int{x: 0}
+	predecessore := make(map[string]string)
+	espansa := make(map[string]bool)
+	coda := &codaPriorita{{parola: x, priorita: stima(x, y)}}
+
+	for coda.Len() > 0 {
+		u := heap.Pop(coda).(elementoCoda)
+		if espansa[u.parola] {
+			continue
+		}
+		if u.parola == y {
+			risultato.Chain = ricostruisciCatena(predecessore, x, y)
+			return risultato, nil
+		}
+		espansa[u.parola] = true
+		risultato.Expanded++
+
+		for _, v := range successori(u.parola) {
+			if espansa[v] {
+				continue
+			}
+			nuova := distanza[u.parola] + 1
+			if dv, ok := distanza[v]; !ok || nuova < dv {
+				distanza[v] = nuova
+				predecessore[v] = u.parola
+				heap.Push(coda, elementoCoda{parola: v, priorita: nuova + stima(v, y), profondita: nuova})
+			}
+		}
+	}
+	return risultato, ErrNoChain
+}
+
+// Una delle due visite della ricerca bidirezionale
+type visita struct {
+	frontiera    []string          // parole dell'ultimo livello raggiunto
+	distanza     map[string]int    // distanza dall'origine delle parole raggiunte
+	predecessore map[string]string // predecessore di ogni parola raggiunta, verso l'origine
+}
+
+// Crea una visita che parte dalla parola origine
+func nuovaVisita(origine string) *visita {
+	return &visita{
+		frontiera:    []string{origine},
+		distanza:     map[string]int{origine: 0},
+		predecessore: make(map[string]string),
+	}
+}
+
+// Cerca la catena tra x e y con due visite in ampiezza, da x e da y, che si incontrano
+// a metà strada, passando da ogni parola alle parole restituite da successori.
+// Ad ogni passo espande per intero il livello della frontiera più piccola:
+// la catena più corta tra quelle che attraversano il livello è di lunghezza minima
+func catenaBidirezionale(x, y string, successori func(string) []string) (ChainResult, error) {
+	var risultato ChainResult
+	avanti, indietro := nuovaVisita(x), nuovaVisita(y)
+
+	for len(avanti.frontiera) > 0 && len(indietro.frontiera) > 0 {
+		corrente, altra := avanti, indietro
+		if len(indietro.frontiera) < len(avanti.frontiera) {
+			corrente, altra = indietro, avanti
+		}
+
+		// Miglior punto di incontro del livello: l'arco u-v con u in corrente e v in altra
+		migliore := -1
+		var incontroU, incontroV string
+
+		var prossima []string
+		for _, u := range corrente.frontiera {
+			risultato.Expanded++
+			for _, v := range successori(u) {
+				if dv, ok := altra.distanza[v]; ok {
+					if lunghezza := corrente.distanza[u] + 1 + dv; migliore < 0 || lunghezza < migliore {
+						migliore, incontroU, incontroV = lunghezza, u, v
+					}
+				}
+				if _, ok := corrente.distanza[v]; ok {
+					continue
+				}
+				corrente.distanza[v] = corrente.distanza[u] + 1
+				corrente.predecessore[v] = u
+				prossima = append(prossima, v)
+			}
+		}
+		corrente.frontiera = prossima
+
+		if migliore >= 0 {
+			if corrente == indietro {
+				incontroU, incontroV = incontroV, incontroU
+			}
+			// incontroU è raggiunta da x, incontroV da y
+			catena := ricostruisciCatena(avanti.predecessore, x, incontroU)
+			for w := incontroV; ; w = indietro.predecessore[w] {
+				catena = append(catena, w)
+				if w == y {
+					break
+				}
+			}
+			risultato.Chain = catena
+			return risultato, nil
+		}
+	}
+	return risultato, ErrNoChain
+}
+
+// Funzione ausiliaria per ricostruire la catena dal predecessore
+func ricostruisciCatena(predecessore map[string]string, inizio, fine string) []string {
+	// Parto dalla fine e risalgo
+	catena := []string{fine}
+	for parola := fine; parola != inizio; parola = predecessore[parola] {
+		catena = append(catena, predecessore[parola])
+	}
+	// Inverto la catena
+	for i, j := 0, len(catena)-1; i < j; i, j = i+1, j-1 {
+		catena[i], catena[j] = catena[j], catena[i]
+	}
+	return catena
+}
@@ -0,0 +1,384 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Verifica che catena sia una catena valida da x a y di parole di d
+func controllaCatena(t *testing.T, d *Dictionary, catena []string, x, y string) {
+	t.Helper()
+	if len(catena) == 0 || catena[0] != x || catena[len(catena)-1] != y {
+		t.Fatalf("catena %v non collega %s e %s", catena, x, y)
+	}
+	for i, w := range catena {
+		if !d.HasWord(w) {
+			t.Fatalf("catena %v: %s non è nel dizionario", catena, w)
+		}
+		if i > 0 && !d.isSimile(catena[i-1], w) {
+			t.Fatalf("catena %v: %s e %s non sono simili", catena, catena[i-1], w)
+		}
+	}
+}
+
+func TestStrategieCatena(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	d := New()
+	parole := paroleCasuali(r, 150, []rune("abcd"))
+	d.Apply(Op{Kind: OpInsert, Entries: parole})
+
+	for i := 0; i < 200; i++ {
+		x, y := parole[r.Intn(len(parole))], parole[r.Intn(len(parole))]
+		bfs, errBFS := d.ChainWith(x, y, ChainOptions{Strategy: StrategyBFS})
+		bid, errBid := d.ChainWith(x, y, ChainOptions{Strategy: StrategyBidirectional})
+		astar, errAStar := d.ChainWith(x, y, ChainOptions{Strategy: StrategyAStar})
+		if errBFS != errBid || errBFS != errAStar {
+			t.Fatalf("%s-%s: errori diversi %v, %v e %v", x, y, errBFS, errBid, errAStar)
+		}
+		if errBFS != nil {
+			continue
+		}
+		controllaCatena(t, d, bfs.Chain, x, y)
+		controllaCatena(t, d, bid.Chain, x, y)
+		controllaCatena(t, d, astar.Chain, x, y)
+		if len(bfs.Chain) != len(bid.Chain) || len(bfs.Chain) != len(astar.Chain) {
+			t.Fatalf("%s-%s: lunghezze diverse %v, %v e %v", x, y, bfs.Chain, bid.Chain, astar.Chain)
+		}
+		if x != y && (bfs.Expanded == 0 || bid.Expanded == 0 || astar.Expanded == 0) {
+			t.Fatalf("%s-%s: parole espanse non conteggiate", x, y)
+		}
+	}
+}
+
+func TestCatenaAStar(t *testing.T) {
+	// Scala da aaaaaa a bbbbbb con molte diramazioni verso parole con c
+	d := New()
+	parola := []byte("aaaaaa")
+	d.Insert(string(parola))
+	for i := range parola {
+		parola[i] = 'b'
+		d.Insert(string(parola))
+		for j := range parola {
+			diramazione := append([]byte(nil), parola...)
+			diramazione[j] = 'c'
+			d.Insert(string(diramazione))
+		}
+	}
+
+	bfs, _ := d.ChainWith("aaaaaa", "bbbbbb", ChainOptions{Strategy: StrategyBFS})
+	astar, err := d.ChainWith("aaaaaa", "bbbbbb", ChainOptions{Strategy: StrategyAStar})
+	if err != nil || len(astar.Chain) != 7 {
+		t.Fatalf("catena A* = %v, %v", astar.Chain, err)
+	}
+	if astar.Expanded >= bfs.Expanded {
+		t.Errorf("A* ha espanso %d parole, la visita in ampiezza %d", astar.Expanded, bfs.Expanded)
+	}
+
+	// Con passi che cambiano la distanza di più di 1 la stima resta ammissibile
+	for _, passo := range []Step{StepTransposition, StepWithin(2), StepAnagram} {
+		attesa, _ := d.ChainWith("aaaaaa", "bbbbbb", ChainOptions{Strategy: StrategyBFS, Step: passo})
+		trovata, _ := d.ChainWith("aaaaaa", "bbbbbb", ChainOptions{Strategy: StrategyAStar, Step: passo})
+		if len(trovata.Chain) != len(attesa.Chain) {
+			t.Errorf("catena A* %v, attesa di lunghezza %d", trovata.Chain, len(attesa.Chain))
+		}
+	}
+}
+
+func TestTutteLeCatene(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "ab", "ba", "bb", "abb", "cc")
+
+	catene, err := d.AllShortestChains("aa", "bb", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	atteso := [][]string{{"aa", "ab", "bb"}, {"aa", "ba", "bb"}}
+	if !reflect.DeepEqual(catene, atteso) {
+		t.Errorf("AllShortestChains = %v, atteso %v", catene, atteso)
+	}
+	if catene, _ := d.AllShortestChains("aa", "bb", 1); !reflect.DeepEqual(catene, atteso[:1]) {
+		t.Errorf("AllShortestChains con limite 1 = %v", catene)
+	}
+	if n, _ := d.CountShortestChains("aa", "bb", 0); n != 2 {
+		t.Errorf("CountShortestChains = %d, atteso 2", n)
+	}
+	if n, _ := d.CountShortestChains("aa", "bb", 1); n != 1 {
+		t.Errorf("CountShortestChains con limite 1 = %d, atteso 1", n)
+	}
+	if _, err := d.AllShortestChains("aa", "cc", 0); err != ErrNoChain {
+		t.Errorf("AllShortestChains(aa, cc) = %v, atteso ErrNoChain", err)
+	}
+}
+
+func TestTutteLeCateneCasuali(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	d := New()
+	parole := paroleCasuali(r, 120, []rune("abc"))
+	d.Apply(Op{Kind: OpInsert, Entries: parole})
+
+	for i := 0; i < 100; i++ {
+		x, y := parole[r.Intn(len(parole))], parole[r.Intn(len(parole))]
+		minima, err := d.Chain(x, y)
+		catene, errTutte := d.AllShortestChains(x, y, 0)
+		n, _ := d.CountShortestChains(x, y, 0)
+		if err != errTutte {
+			t.Fatalf("%s-%s: errori diversi %v e %v", x, y, err, errTutte)
+		}
+		if err != nil {
+			continue
+		}
+		if n != len(catene) {
+			t.Fatalf("%s-%s: conteggio %d, catene %d", x, y, n, len(catene))
+		}
+		for j, c := range catene {
+			controllaCatena(t, d, c, x, y)
+			if len(c) != len(minima) {
+				t.Fatalf("%s-%s: catena %v non minima", x, y, c)
+			}
+			// Ordine lessicografico stretto: catene distinte e deterministiche
+			if j > 0 && !menoDi(catene[j-1], c) {
+				t.Fatalf("%s-%s: catene non ordinate %v %v", x, y, catene[j-1], c)
+			}
+		}
+	}
+}
+
+// Restituisce true se la catena a precede b in ordine lessicografico
+func menoDi(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// Restituisce le lunghezze ordinate di tutte le catene senza parole ripetute tra x e y
+func lunghezzeCateneSemplici(d *Dictionary, x, y string) []int {
+	var lunghezze []int
+	visitato := map[string]bool{x: true}
+	var visita func(w string, n int)
+	visita = func(w string, n int) {
+		if w == y {
+			lunghezze = append(lunghezze, n)
+			return
+		}
+		for _, v := range d.vicini(w) {
+			if !visitato[v] {
+				visitato[v] = true
+				visita(v, n+1)
+				visitato[v] = false
+			}
+		}
+	}
+	visita(x, 1)
+	sort.Ints(lunghezze)
+	return lunghezze
+}
+
+func TestKCatene(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "ab", "ba", "bb", "cb", "ca", "abb", "zz")
+	lunghezze := lunghezzeCateneSemplici(d, "aa", "bb")
+
+	catene, err := d.KShortestChains("aa", "bb", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Con k grande si ottengono tutte le catene senza ripetizioni, in ordine di lunghezza
+	if len(catene) != len(lunghezze) {
+		t.Fatalf("KShortestChains = %d catene, attese %d", len(catene), len(lunghezze))
+	}
+	visto := make(map[string]bool)
+	for i, c := range catene {
+		controllaCatena(t, d, c, "aa", "bb")
+		if len(c) != lunghezze[i] {
+			t.Errorf("catena %d %v di lunghezza %d, attesa %d", i, c, len(c), lunghezze[i])
+		}
+		parole := make(map[string]bool)
+		for _, w := range c {
+			if parole[w] {
+				t.Errorf("catena %v con parole ripetute", c)
+			}
+			parole[w] = true
+		}
+		if i > 0 && len(c) == len(catene[i-1]) && chiaveCatena(c) < chiaveCatena(catene[i-1]) {
+			t.Errorf("catene %v e %v non in ordine lessicografico", catene[i-1], c)
+		}
+		if visto[chiaveCatena(c)] {
+			t.Errorf("catena %v ripetuta", c)
+		}
+		visto[chiaveCatena(c)] = true
+	}
+
+	if catene, _ := d.KShortestChains("aa", "bb", 3); len(catene) != 3 || len(catene[2]) != lunghezze[2] {
+		t.Errorf("KShortestChains con k = 3: %v", catene)
+	}
+	// A parità di lunghezza le catene sono in ordine lessicografico, come in AllShortestChains
+	for _, c := range [][2]string{{"bb", "aa"}, {"aa", "bb"}, {"ca", "abb"}} {
+		tutte, _ := d.AllShortestChains(c[0], c[1], 0)
+		k, _ := d.KShortestChains(c[0], c[1], len(tutte))
+		if !reflect.DeepEqual(k, tutte) {
+			t.Errorf("KShortestChains(%s, %s) = %v, attese %v", c[0], c[1], k, tutte)
+		}
+	}
+	piccolo := nuovoDizionario(t, "aa", "ab", "ba", "bb")
+	atteso := [][]string{{"bb", "ab", "aa"}, {"bb", "ba", "aa"}}
+	if k, _ := piccolo.KShortestChains("bb", "aa", 2); !reflect.DeepEqual(k, atteso) {
+		t.Errorf("KShortestChains(bb, aa, 2) = %v, atteso %v", k, atteso)
+	}
+	if _, err := d.KShortestChains("aa", "zz", 3); err != ErrNoChain {
+		t.Errorf("KShortestChains(aa, zz) = %v, atteso ErrNoChain", err)
+	}
+}
+
+// Restituisce una parola che compare più volte nella catena, "" se non ce ne sono
+func parolaRipetuta(catena []string) string {
+	vista := make(map[string]bool)
+	for _, w := range catena {
+		if vista[w] {
+			return w
+		}
+		vista[w] = true
+	}
+	return ""
+}
+
+func TestVincoliCatena(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "ab", "bb", "ba", "ca", "cb", "zz")
+
+	casi := []struct {
+		nome    string
+		opzioni ChainOptions
+		atteso  []string
+	}{
+		{"parola vietata", ChainOptions{Avoid: []string{"ab"}}, []string{"aa", "ba", "bb"}},
+		{"deviazione", ChainOptions{Avoid: []string{"ab", "ba"}}, []string{"aa", "ca", "cb", "bb"}},
+		{"tappa", ChainOptions{Via: []string{"cb"}, Avoid: []string{"ca"}}, []string{"aa", "ab", "cb", "bb"}},
+		{"tappe in ordine", ChainOptions{Via: []string{"ca", "cb"}}, []string{"aa", "ca", "cb", "bb"}},
+		{"tappa ripetuta consecutiva", ChainOptions{Via: []string{"ab", "ab"}}, []string{"aa", "ab", "bb"}},
+		// Tra ca e ab non si può ripassare per aa, né per la tappa successiva bb
+		{"tappe che richiedono ripetizioni", ChainOptions{Via: []string{"ca", "ab"}, Avoid: []string{"cb"}}, nil},
+		{"tappa già attraversata", ChainOptions{Via: []string{"ab", "aa"}}, nil},
+		{"tappa successiva evitata", ChainOptions{Via: []string{"ba", "ab"}}, []string{"aa", "ba", "ca", "cb", "ab", "bb"}},
+		{"tappa vietata", ChainOptions{Via: []string{"cb"}, Avoid: []string{"cb"}}, nil},
+		{"catena impossibile", ChainOptions{Avoid: []string{"ab", "ba", "cb"}}, nil},
+		{"tappa assente", ChainOptions{Via: []string{"xy"}}, nil},
+	}
+	for _, c := range casi {
+		for _, s := range []Strategy{StrategyBidirectional, StrategyBFS, StrategyAStar} {
+			c.opzioni.Strategy = s
+			risultato, err := d.ChainWith("aa", "bb", c.opzioni)
+			if c.atteso == nil {
+				if err != ErrNoChain {
+					t.Errorf("%s: ChainWith = %v, %v, atteso ErrNoChain", c.nome, risultato.Chain, err)
+				}
+				continue
+			}
+			if err != nil || len(risultato.Chain) != len(c.atteso) {
+				t.Errorf("%s: ChainWith = %v, %v, atteso %v", c.nome, risultato.Chain, err, c.atteso)
+				continue
+			}
+			controllaCatena(t, d, risultato.Chain, "aa", "bb")
+			if ripetuta := parolaRipetuta(risultato.Chain); ripetuta != "" {
+				t.Errorf("%s: ChainWith = %v ripete %s", c.nome, risultato.Chain, ripetuta)
+			}
+			if c.nome != "parola vietata" && !reflect.DeepEqual(risultato.Chain, c.atteso) {
+				t.Errorf("%s: ChainWith = %v, atteso %v", c.nome, risultato.Chain, c.atteso)
+			}
+		}
+	}
+}
+
+// Restituisce true se la catena senza ripetizioni da x a y catena evita le parole vietate
+// delle opzioni e ne attraversa le tappe nell'ordine dato, cioè le contiene come sottosequenza
+func rispettaVincoli(catena []string, x, y string, opzioni ChainOptions) bool {
+	vietata := make(map[string]bool)
+	for _, w := range opzioni.Avoid {
+		vietata[w] = true
+	}
+	ordine := append(append([]string{x}, opzioni.Via...), y)
+	j := 0
+	for _, w := range catena {
+		if vietata[w] {
+			return false
+		}
+		for j < len(ordine) && ordine[j] == w {
+			j++
+		}
+	}
+	return j == len(ordine)
+}
+
+// Restituisce true se esiste una catena senza ripetizioni da x a y che rispetta i vincoli
+// delle opzioni, enumerando tutte le catene senza ripetizioni
+func esisteCatenaVincolata(d *Dictionary, x, y string, opzioni ChainOptions) bool {
+	catena := []string{x}
+	visitato := map[string]bool{x: true}
+	var visita func(w string) bool
+	visita = func(w string) bool {
+		if w == y {
+			return rispettaVincoli(catena, x, y, opzioni)
+		}
+		for _, v := range d.vicini(w) {
+			if visitato[v] {
+				continue
+			}
+			visitato[v] = true
+			catena = append(catena, v)
+			if visita(v) {
+				return true
+			}
+			catena = catena[:len(catena)-1]
+			visitato[v] = false
+		}
+		return false
+	}
+	return visita(x)
+}
+
+func TestTappeCasuali(t *testing.T) {
+	// Il tratto minimo aab-aa-ba-bba chiude le strade verso aaab: serve un tratto più lungo
+	d := nuovoDizionario(t, "aa", "aaab", "aab", "ab", "abab", "abbb", "b", "ba", "baa", "bab", "bba", "bbaa")
+	opzioni := ChainOptions{Via: []string{"bba"}}
+	risultato, err := d.ChainWith("aab", "aaab", opzioni)
+	if err != nil || !rispettaVincoli(risultato.Chain, "aab", "aaab", opzioni) {
+		t.Fatalf("ChainWith(aab, aaab, via bba) = %v, %v", risultato.Chain, err)
+	}
+	controllaCatena(t, d, risultato.Chain, "aab", "aaab")
+
+	r := rand.New(rand.NewSource(6))
+	for i := 0; i < 300; i++ {
+		d := New()
+		parole := paroleCasuali(r, 16, []rune("abc"))
+		d.Apply(Op{Kind: OpInsert, Entries: parole})
+		scegli := func(n int) []string {
+			scelte := make([]string, n)
+			for j := range scelte {
+				scelte[j] = parole[r.Intn(len(parole))]
+			}
+			return scelte
+		}
+		x, y := parole[r.Intn(len(parole))], parole[r.Intn(len(parole))]
+		opzioni := ChainOptions{Via: scegli(r.Intn(4)), Avoid: scegli(r.Intn(3))}
+		esiste := esisteCatenaVincolata(d, x, y, opzioni)
+
+		for _, s := range []Strategy{StrategyBidirectional, StrategyBFS, StrategyAStar} {
+			opzioni.Strategy = s
+			risultato, err := d.ChainWith(x, y, opzioni)
+			if (err == nil) != esiste {
+				t.Fatalf("%s-%s %+v: ChainWith = %v, %v, esistenza attesa %v", x, y, opzioni, risultato.Chain, err, esiste)
+			}
+			if err != nil {
+				continue
+			}
+			controllaCatena(t, d, risultato.Chain, x, y)
+			if ripetuta := parolaRipetuta(risultato.Chain); ripetuta != "" {
+				t.Fatalf("%s-%s %+v: ChainWith = %v ripete %s", x, y, opzioni, risultato.Chain, ripetuta)
+			}
+			if !rispettaVincoli(risultato.Chain, x, y, opzioni) {
+				t.Fatalf("%s-%s %+v: ChainWith = %v non rispetta i vincoli", x, y, opzioni, risultato.Chain)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package parole
+
+import (
+	"math"
+	"sort"
+)
+
+// Grafo a livelli delle catene di lunghezza minima tra due parole: contiene solo le
+// parole che compaiono in almeno una catena minima, ciascuna con i successori ordinati
+type grafoMinimo struct {
+	successori map[string][]string
+}
+
+// Costruisce il grafo a livelli delle catene di lunghezza minima tra x e y con una
+// visita in ampiezza da x che si ferma al livello di y
+func (d *Dictionary) grafoMinimo(x, y string) (*grafoMinimo, error) {
+	if !d.HasWord(x) || !d.HasWord(y) {
+		return nil, ErrNoChain
+	}
+
+	distanza := map[string]int{x: 0}
+	predecessori := make(map[string][]string)
+	livello := []string{x}
+	for len(livello) > 0 {
+		if _, ok := distanza[y]; ok {
+			break
+		}
+		var prossimo []string
+		for _, u := range livello {
+			for _, v := range d.vicini(u) {
+				dv, ok := distanza[v]
+				if !ok {
+					dv = distanza[u] + 1
+					distanza[v] = dv
+					prossimo = append(prossimo, v)
+				}
+				if dv == distanza[u]+1 {
+					predecessori[v] = append(predecessori[v], u)
+				}
+			}
+		}
+		livello = prossimo
+	}
+	if _, ok := distanza[y]; !ok {
+		return nil, ErrNoChain
+	}
+
+	// Risalgo da y: gli archi percorsi sono quelli delle catene minime
+	g := &grafoMinimo{successori: make(map[string][]string)}
+	visitato := map[string]bool{y: true}
+	coda := []string{y}
+	for len(coda) > 0 {
+		v := coda[0]
+		coda = coda[1:]
+		for _, u := range predecessori[v] {
+			g.successori[u] = append(g.successori[u], v)
+			if !visitato[u] {
+				visitato[u] = true
+				coda = append(coda, u)
+			}
+		}
+	}
+	for _, s := range g.successori {
+		sort.Strings(s)
+	}
+	return g, nil
+}
+
+// Restituisce le catene di lunghezza minima tra le parole x e y in ordine lessicografico,
+// al più limite catene (tutte se limite <= 0).
+// Restituisce ErrNoChain se x o y non sono nel dizionario o se la catena non esiste
+func (d *Dictionary) AllShortestChains(x, y string, limite int) ([][]string, error) {
+	g, err := d.grafoMinimo(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	var catene [][]string
+	var percorso []string
+	// Visita in profondità dei successori in ordine alfabetico
+	var visita func(w string) bool
+	visita = func(w string) bool {
+		percorso = append(percorso, w)
+		defer func() { percorso = percorso[:len(percorso)-1] }()
+		if w == y {
+			catene = append(catene, append([]string(nil), percorso...))
+			return limite <= 0 || len(catene) < limite
+		}
+		for _, v := range g.successori[w] {
+			if !visita(v) {
+				return false
+			}
+		}
+		return true
+	}
+	visita(x)
+	return catene, nil
+}
+
+// Restituisce il numero di catene di lunghezza minima tra le parole x e y, al più limite
+// (se limite <= 0 il conteggio satura al massimo valore di int).
+// Restituisce ErrNoChain se x o y non sono nel dizionario o se la catena non esiste
+func (d *Dictionary) CountShortestChains(x, y string, limite int) (int, error) {
+	g, err := d.grafoMinimo(x, y)
+	if err != nil {
+		return 0, err
+	}
+	if limite <= 0 {
+		limite = math.MaxInt
+	}
+
+	// Numero di catene minime da ogni parola a y, calcolato a partire da y
+	conteggio := map[string]int{y: 1}
+	var conta func(w string) int
+	conta = func(w string) int {
+		if c, ok := conteggio[w]; ok {
+			return c
+		}
+		c := 0
+		for _, v := range g.successori[w] {
+			c += conta(v)
+			if c >= limite || c < 0 {
+				c = limite
+				break
+			}
+		}
+		conteggio[w] = c
+		return c
+	}
+	return conta(x), nil
+}
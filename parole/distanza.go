@@ -0,0 +1,57 @@
+package parole
+
+// Calcola il minimo tra gli interi a, b, c
+func min3(a, b, c int) int {
+	if a < b {
+		if a < c {
+			return a
+		}
+		return c
+	}
+	if b < c {
+		return b
+	}
+	return c
+}
+
+// Restituisce la distanza di editing tra le stringhe s1 ed s2, utilizzando l'algoritmo di Levenshtein.
+// In modalità Unicode la distanza è calcolata sulle rune, altrimenti sui byte
+func (d *Dictionary) Distance(s1, s2 string) int {
+	return distanza(d.simboli(s1), d.simboli(s2))
+}
+
+// Implementazione della distanza di Levenshtein su due righe della matrice
+func distanza(s1, s2 []rune) int {
+	m := len(s1)
+	n := len(s2)
+
+	if n == 0 {
+		return m
+	}
+	if m == 0 {
+		return n
+	}
+
+	prev := make([]int, n+1)
+	curr := make([]int, n+1)
+
+	for j := 0; j <= n; j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= m; i++ {
+		curr[0] = i
+		for j := 1; j <= n; j++ {
+			costo := 0
+			if s1[i-1] != s2[j-1] {
+				costo = 1
+			}
+
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+costo)
+		}
+		// Scambio dei riferimenti tra prev e curr
+		prev, curr = curr, prev
+	}
+
+	return prev[n]
+}
@@ -0,0 +1,325 @@
+// Package parole implementa il dizionario di parole e schemi del progetto
+// "Parole e catene di parole": inserimento, eliminazione, ricerca per schema,
+// distanza di editing e catene di parole simili.
+package parole
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"regexp"
+	"sort"
+	"unicode"
+)
+
+// Errori restituiti dalle operazioni sul dizionario
+var (
+	ErrNonValida = errors.New("parole: parola/schema non valida")
+	ErrNoChain   = errors.New("parole: catena non esistente")
+)
+
+// Espressione regolare per parole e schemi sull'alfabeto inglese
+var regexValida = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// Dizionario contenente le parole e gli schemi
+type Dictionary struct {
+	parole map[string]struct{}
+	schemi map[string]struct{}
+
+	// Indici delle parole e degli schemi, aggiornati da inserimenti ed eliminazioni
+	indice    *indiceVicini                          // parole a distanza di editing 1
+	anagrammi map[string]map[string]struct{}         // parole per multiinsieme di simboli
+	posizioni *indicePosizioni                       // parole per lunghezza e simbolo in ogni posizione
+	forme     *indiceSchemi                          // schemi per lunghezza e posizioni dei simboli fissi
+	modelli   map[int]map[string]map[string]struct{} // parole per lunghezza e modello
+
+	// In modalità Unicode le lettere sono tutte le lettere Unicode, le variabili degli
+	// schemi sono le lettere maiuscole e distanza e compatibilità operano sulle rune
+	unicode bool
+}
+
+// Crea un nuovo dizionario vuoto sull'alfabeto inglese
+func New() *Dictionary {
+	d := &Dictionary{}
+	d.Reset()
+	return d
+}
+
+// Crea un nuovo dizionario vuoto in modalità Unicode
+func NewUnicode() *Dictionary {
+	d := New()
+	d.unicode = true
+	return d
+}
+
+// Crea un nuovo dizionario vuoto nella stessa modalità del dizionario d
+func (d *Dictionary) NewEmpty() *Dictionary {
+	if d.unicode {
+		return NewUnicode()
+	}
+	return New()
+}
+
+// Restituisce true se il dizionario d è in modalità Unicode
+func (d *Dictionary) Unicode() bool {
+	return d.unicode
+}
+
+// Svuota il dizionario d assegnando a ciascuna mappa una nuova mappa vuota
+func (d *Dictionary) Reset() {
+	d.parole = make(map[string]struct{})
+	d.schemi = make(map[string]struct{})
+	d.nuoviIndici()
+}
+
+// Assegna a ciascun indice delle parole un nuovo indice vuoto
+func (d *Dictionary) nuoviIndici() {
+	d.indice = nuovoIndiceVicini()
+	d.anagrammi = make(map[string]map[string]struct{})
+	d.posizioni = nuovoIndicePosizioni()
+	d.forme = nuovoIndiceSchemi()
+	d.modelli = make(map[int]map[string]map[string]struct{})
+}
+
+// Ricostruisce gli indici di tutte le parole e gli schemi di d. Le mappe sono create già
+// della dimensione finale, evitando le riallocazioni dell'indicizzazione una voce alla volta
+func (d *Dictionary) ricostruisciIndici() {
+	lunghezzaTotale := 0
+	dimensioni := make(map[chiavePosizione]int)
+	for w := range d.parole {
+		s := d.simboli(w)
+		lunghezzaTotale += len(s)
+		dimensioni[chiaveLunghezza(len(s))]++
+		for i, c := range s {
+			dimensioni[chiavePosizione{int32(len(s)), int32(i), c}]++
+		}
+	}
+
+	d.indice = &indiceVicini{secchielli: make(map[string][]occorrenza, lunghezzaTotale)}
+	d.anagrammi = make(map[string]map[string]struct{}, len(d.parole))
+	d.posizioni = &indicePosizioni{liste: make(map[chiavePosizione]map[string]struct{}, len(dimensioni))}
+	for k, n := range dimensioni {
+		d.posizioni.liste[k] = make(map[string]struct{}, n)
+	}
+	d.forme = nuovoIndiceSchemi()
+	d.modelli = make(map[int]map[string]map[string]struct{})
+	for w := range d.parole {
+		d.indicizza(w)
+	}
+	for s := range d.schemi {
+		d.indicizzaSchema(s)
+	}
+}
+
+// Registra negli indici la parola w
+func (d *Dictionary) indicizza(w string) {
+	s := d.simboli(w)
+	d.indice.aggiungi(w, s)
+	chiave := chiaveAnagramma(s)
+	if d.anagrammi[chiave] == nil {
+		d.anagrammi[chiave] = make(map[string]struct{})
+	}
+	d.anagrammi[chiave][w] = struct{}{}
+	d.posizioni.aggiungi(w, s)
+	m := modello(s)
+	if d.modelli[len(s)] == nil {
+		d.modelli[len(s)] = make(map[string]map[string]struct{})
+	}
+	if d.modelli[len(s)][m] == nil {
+		d.modelli[len(s)][m] = make(map[string]struct{})
+	}
+	d.modelli[len(s)][m][w] = struct{}{}
+}
+
+// Rimuove dagli indici la parola w
+func (d *Dictionary) deindicizza(w string) {
+	s := d.simboli(w)
+	d.indice.rimuovi(w, s)
+	chiave := chiaveAnagramma(s)
+	delete(d.anagrammi[chiave], w)
+	if len(d.anagrammi[chiave]) == 0 {
+		delete(d.anagrammi, chiave)
+	}
+	d.posizioni.rimuovi(w, s)
+	m := modello(s)
+	delete(d.modelli[len(s)][m], w)
+	if len(d.modelli[len(s)][m]) == 0 {
+		delete(d.modelli[len(s)], m)
+		if len(d.modelli[len(s)]) == 0 {
+			delete(d.modelli, len(s))
+		}
+	}
+}
+
+// Registra negli indici lo schema s
+func (d *Dictionary) indicizzaSchema(s string) {
+	d.forme.aggiungi(s, d.simboli(s), d.variabile)
+}
+
+// Rimuove dagli indici lo schema s
+func (d *Dictionary) deindicizzaSchema(s string) {
+	d.forme.rimuovi(s, d.simboli(s), d.variabile)
+}
+
+// Controlla se una stringa w appartiene all'alfabeto inglese minuscolo o maiuscolo
+func IsValid(w string) bool {
+	return regexValida.MatchString(w)
+}
+
+// Se la stringa s contiene almeno una lettera maiuscola dell'alfabeto inglese restituisce true, false altrimenti
+func IsSchema(s string) bool {
+	for _, c := range s {
+		if isMaiuscola(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Restituisce true se la runa r è maiuscola, false altrimenti
+func isMaiuscola(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
+
+// Controlla se la stringa w è una parola/schema valida nella modalità del dizionario d
+func (d *Dictionary) IsValid(w string) bool {
+	if !d.unicode {
+		return IsValid(w)
+	}
+	for _, c := range w {
+		if !unicode.IsLetter(c) {
+			return false
+		}
+	}
+	return w != ""
+}
+
+// Restituisce true se la stringa s contiene almeno una variabile nella modalità del dizionario d
+func (d *Dictionary) IsSchema(s string) bool {
+	for _, c := range s {
+		if d.variabile(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Restituisce true se la runa r è una variabile degli schemi nella modalità del dizionario d
+func (d *Dictionary) variabile(r rune) bool {
+	if d.unicode {
+		return unicode.IsUpper(r)
+	}
+	return isMaiuscola(r)
+}
+
+// Restituisce i simboli della stringa w su cui operano distanza e compatibilità:
+// le rune in modalità Unicode, i singoli byte altrimenti
+func (d *Dictionary) simboli(w string) []rune {
+	if d.unicode {
+		return []rune(w)
+	}
+	simboli := make([]rune, len(w))
+	for i := 0; i < len(w); i++ {
+		simboli[i] = rune(w[i])
+	}
+	return simboli
+}
+
+// Verifica l'esistenza di una parola w all'interno del dizionario d
+func (d *Dictionary) HasWord(w string) bool {
+	_, esiste := d.parole[w]
+	return esiste
+}
+
+// Verifica l'esistenza di uno schema w all'interno del dizionario d
+func (d *Dictionary) HasSchema(w string) bool {
+	_, esiste := d.schemi[w]
+	return esiste
+}
+
+// Inserisce all'interno del dizionario d la parola/schema w, se valida
+func (d *Dictionary) Insert(w string) error {
+	if !d.IsValid(w) {
+		return ErrNonValida
+	}
+	if d.IsSchema(w) {
+		if !d.HasSchema(w) {
+			d.schemi[w] = struct{}{}
+			d.indicizzaSchema(w)
+		}
+	} else {
+		if !d.HasWord(w) {
+			d.parole[w] = struct{}{}
+			d.indicizza(w)
+		}
+	}
+	return nil
+}
+
+// Se presente, elimina la parola/schema w dal dizionario d; restituisce true se w era presente
+func (d *Dictionary) Delete(w string) bool {
+	if d.IsSchema(w) {
+		if !d.HasSchema(w) {
+			return false
+		}
+		delete(d.schemi, w)
+		d.deindicizzaSchema(w)
+	} else {
+		if !d.HasWord(w) {
+			return false
+		}
+		delete(d.parole, w)
+		d.deindicizza(w)
+	}
+	return true
+}
+
+// Carica sul dizionario d le parole/schemi del file nome.
+// Restituisce le stringhe scartate perché in formato errato
+func (d *Dictionary) Load(nome string) ([]string, error) {
+	voci, scartate, err := d.ReadFile(nome)
+	d.Apply(Op{Kind: OpInsert, Entries: voci})
+	return scartate, err
+}
+
+// Legge le parole/schemi del file nome separati da spazi, senza modificare il dizionario d.
+// Restituisce le voci valide nella modalità di d e le stringhe scartate perché in formato errato
+func (d *Dictionary) ReadFile(nome string) (voci, scartate []string, err error) {
+	f, err := os.Open(nome)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		w := scanner.Text()
+		if d.IsValid(w) {
+			voci = append(voci, w)
+		} else {
+			scartate = append(scartate, w)
+		}
+	}
+	return voci, scartate, scanner.Err()
+}
+
+// Restituisce le parole presenti nel dizionario d, in ordine alfabetico
+func (d *Dictionary) Words() []string {
+	return ordinate(d.parole)
+}
+
+// Restituisce gli schemi presenti nel dizionario d, in ordine alfabetico
+func (d *Dictionary) Schemas() []string {
+	return ordinate(d.schemi)
+}
+
+// Restituisce le chiavi dell'insieme s in ordine alfabetico
+func ordinate(s map[string]struct{}) []string {
+	chiavi := make([]string, 0, len(s))
+	for k := range s {
+		chiavi = append(chiavi, k)
+	}
+	sort.Strings(chiavi)
+	return chiavi
+}
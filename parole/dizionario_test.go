@@ -0,0 +1,252 @@
+package parole
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Crea un dizionario contenente le stringhe voci
+func nuovoDizionario(t *testing.T, voci ...string) *Dictionary {
+	t.Helper()
+	d := New()
+	for _, w := range voci {
+		if err := d.Insert(w); err != nil {
+			t.Fatalf("Insert(%q): %v", w, err)
+		}
+	}
+	return d
+}
+
+func TestInserisciElimina(t *testing.T) {
+	d := nuovoDizionario(t, "a", "b", "a", "Aa", "aB")
+
+	if got, want := d.Words(), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Words() = %v, atteso %v", got, want)
+	}
+	if got, want := d.Schemas(), []string{"Aa", "aB"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Schemas() = %v, atteso %v", got, want)
+	}
+	if err := d.Insert("a1"); err != ErrNonValida {
+		t.Errorf("Insert(a1) = %v, atteso ErrNonValida", err)
+	}
+
+	if !d.Delete("a") || d.HasWord("a") {
+		t.Errorf("Delete(a) non ha eliminato la parola")
+	}
+	if d.Delete("a") {
+		t.Errorf("Delete(a) su parola assente ha restituito true")
+	}
+	if !d.Delete("Aa") || d.HasSchema("Aa") {
+		t.Errorf("Delete(Aa) non ha eliminato lo schema")
+	}
+
+	d.Reset()
+	if len(d.Words()) != 0 || len(d.Schemas()) != 0 {
+		t.Errorf("Reset() non ha svuotato il dizionario")
+	}
+}
+
+func TestCarica(t *testing.T) {
+	nome := filepath.Join(t.TempDir(), "dizionario")
+	if err := os.WriteFile(nome, []byte("abba ABCa\nab1 abca"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := New()
+	scartate, err := d.Load(nome)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if want := []string{"ab1"}; !reflect.DeepEqual(scartate, want) {
+		t.Errorf("scartate = %v, atteso %v", scartate, want)
+	}
+	if got, want := d.Words(), []string{"abba", "abca"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Words() = %v, atteso %v", got, want)
+	}
+	if _, err := d.Load(filepath.Join(t.TempDir(), "assente")); err == nil {
+		t.Errorf("Load su file assente non ha restituito errore")
+	}
+}
+
+func TestRicerca(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "ab", "abba", "ba")
+
+	casi := []struct {
+		schema string
+		atteso []string
+	}{
+		{"aC", []string{"aa", "ab"}},
+		{"AA", []string{"aa"}},
+		{"ABBA", []string{"abba"}},
+		{"cA", nil},
+	}
+	for _, c := range casi {
+		if got := d.Match(c.schema); !reflect.DeepEqual(got, c.atteso) {
+			t.Errorf("Match(%s) = %v, atteso %v", c.schema, got, c.atteso)
+		}
+	}
+}
+
+func TestRicercaIniettiva(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "ab", "ba", "abba", "abca", "acca", "cbbc", "dbbd", "bbbb")
+
+	casi := []struct {
+		schema string
+		atteso []string
+	}{
+		{"AB", []string{"ab", "ba"}},
+		{"AA", []string{"aa"}},
+		{"ABBA", []string{"abba", "acca", "cbbc", "dbbd"}},
+		{"AbbA", []string{"abba", "cbbc", "dbbd"}},
+		{"aBCa", []string{"abca"}},
+		{"aBBa", []string{"abba", "acca"}},
+	}
+	for _, c := range casi {
+		if got := d.MatchWith(c.schema, MatchInjective); !reflect.DeepEqual(got, c.atteso) {
+			t.Errorf("MatchWith(%s, MatchInjective) = %v, atteso %v", c.schema, got, c.atteso)
+		}
+	}
+	if d.CompatibleWith("aa", "AB", MatchInjective) || !d.Compatible("aa", "AB") {
+		t.Errorf("aa e AB sono compatibili solo nella modalità predefinita")
+	}
+}
+
+func TestDistanza(t *testing.T) {
+	d := New()
+	casi := []struct {
+		x, y   string
+		atteso int
+	}{
+		{"aa", "aba", 1},
+		{"aa", "aa", 0},
+		{"", "abc", 3},
+		{"pesce", "sedia", 4},
+	}
+	for _, c := range casi {
+		if got := d.Distance(c.x, c.y); got != c.atteso {
+			t.Errorf("Distance(%s, %s) = %d, atteso %d", c.x, c.y, got, c.atteso)
+		}
+	}
+}
+
+func TestCatena(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "aaa", "aba", "bba", "cca")
+
+	catena, err := d.Chain("aa", "bba")
+	if err != nil {
+		t.Fatalf("Chain(aa, bba): %v", err)
+	}
+	if want := []string{"aa", "aba", "bba"}; !reflect.DeepEqual(catena, want) {
+		t.Errorf("Chain(aa, bba) = %v, atteso %v", catena, want)
+	}
+	if catena, _ := d.Chain("aa", "aa"); !reflect.DeepEqual(catena, []string{"aa"}) {
+		t.Errorf("Chain(aa, aa) = %v", catena)
+	}
+	if _, err := d.Chain("aa", "cca"); err != ErrNoChain {
+		t.Errorf("Chain(aa, cca) = %v, atteso ErrNoChain", err)
+	}
+	if _, err := d.Chain("aa", "zz"); err != ErrNoChain {
+		t.Errorf("Chain(aa, zz) = %v, atteso ErrNoChain", err)
+	}
+}
+
+func TestSalva(t *testing.T) {
+	nome := filepath.Join(t.TempDir(), "dizionario")
+	d := nuovoDizionario(t, "abba", "abca", "ABCa", "aB")
+	if err := d.Save(nome); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	riletto := New()
+	if scartate, err := riletto.Load(nome); err != nil || len(scartate) != 0 {
+		t.Fatalf("Load = %v, %v", scartate, err)
+	}
+	if !reflect.DeepEqual(riletto.Words(), d.Words()) || !reflect.DeepEqual(riletto.Schemas(), d.Schemas()) {
+		t.Errorf("dizionario riletto %v %v, atteso %v %v", riletto.Words(), riletto.Schemas(), d.Words(), d.Schemas())
+	}
+
+	if err := d.Save(filepath.Join(t.TempDir(), "manca", "dizionario")); err == nil {
+		t.Errorf("Save su directory inesistente non ha restituito errore")
+	}
+	// Un file nuovo ha permessi 0644, uno sostituito conserva i suoi
+	if info, _ := os.Stat(nome); info.Mode().Perm() != 0o644 {
+		t.Errorf("permessi del file salvato %v, attesi 0644", info.Mode().Perm())
+	}
+	os.Chmod(nome, 0o640)
+	d.Save(nome)
+	if info, _ := os.Stat(nome); info.Mode().Perm() != 0o640 {
+		t.Errorf("permessi del file sostituito %v, attesi 0640", info.Mode().Perm())
+	}
+
+	// Dopo il salvataggio non devono restare file temporanei
+	voci, _ := os.ReadDir(filepath.Dir(nome))
+	if len(voci) != 1 {
+		t.Errorf("file temporanei rimasti: %v", voci)
+	}
+}
+
+func TestCopia(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "AB")
+	copia := d.Clone()
+	copia.Insert("bb")
+	d.Delete("AB")
+	if got, want := copia.Entries(), []string{"aa", "bb", "AB"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("copia %v, attesa %v", got, want)
+	}
+	if got, want := d.Entries(), []string{"aa"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("originale %v, atteso %v", got, want)
+	}
+}
+
+func TestUnicode(t *testing.T) {
+	nome := filepath.Join(t.TempDir(), "dizionario")
+	if err := os.WriteFile(nome, []byte("abc dèf\nbca cfòabba ÀbbÀ 1a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ascii := New()
+	if scartate, _ := ascii.Load(nome); !reflect.DeepEqual(scartate, []string{"dèf", "cfòabba", "ÀbbÀ", "1a"}) {
+		t.Errorf("scartate in modalità ASCII: %v", scartate)
+	}
+
+	d := NewUnicode()
+	if scartate, _ := d.Load(nome); !reflect.DeepEqual(scartate, []string{"1a"}) {
+		t.Errorf("scartate in modalità Unicode: %v", scartate)
+	}
+	if got, want := d.Words(), []string{"abc", "bca", "cfòabba", "dèf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Words() = %v, atteso %v", got, want)
+	}
+	if got, want := d.Schemas(), []string{"ÀbbÀ"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Schemas() = %v, atteso %v", got, want)
+	}
+
+	// La distanza opera sulle rune solo in modalità Unicode
+	if got := d.Distance("dèf", "def"); got != 1 {
+		t.Errorf("Distance Unicode = %d, atteso 1", got)
+	}
+	if got := ascii.Distance("dèf", "def"); got != 2 {
+		t.Errorf("Distance ASCII = %d, atteso 2", got)
+	}
+
+	d.Insert("èllè")
+	d.Insert("òllè")
+	if got, want := d.Match("ÉllÉ"), []string{"èllè"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Match(ÉllÉ) = %v, atteso %v", got, want)
+	}
+	if catena, err := d.Chain("èllè", "òllè"); err != nil || len(catena) != 2 {
+		t.Errorf("Chain(èllè, òllè) = %v, %v", catena, err)
+	}
+
+	// La modalità è conservata dalla copia e dallo snapshot
+	if !d.Clone().Unicode() {
+		t.Errorf("Clone() non conserva la modalità Unicode")
+	}
+	snapshot := filepath.Join(t.TempDir(), "snapshot")
+	d.SaveSnapshot(snapshot)
+	riletto := New()
+	if err := riletto.LoadSnapshot(snapshot); err != nil || !riletto.Unicode() || !riletto.HasWord("dèf") {
+		t.Errorf("LoadSnapshot non conserva la modalità Unicode: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package parole
+
+import (
+	"fmt"
+	"io"
+)
+
+// Scrive su w l'insieme voci, una voce per riga racchiuse tra parentesi quadre
+func WriteSet(w io.Writer, voci []string) error {
+	return scriviRacchiuse(w, "[", voci, "]")
+}
+
+// Scrive su w lo schema schema seguito da ":" e dall'insieme delle parole compatibili.
+// Scrive allo stesso modo una parola seguita dagli schemi compatibili
+func WriteMatch(w io.Writer, schema string, parole []string) error {
+	return scriviRacchiuse(w, schema+":[", parole, "]")
+}
+
+// Scrive su w la catena catena, una parola per riga racchiuse tra parentesi tonde.
+// Se err non è nil scrive "non esiste"
+func WriteChain(w io.Writer, catena []string, err error) error {
+	if err != nil {
+		_, err = fmt.Fprintln(w, "non esiste")
+		return err
+	}
+	return scriviRacchiuse(w, "(", catena, ")")
+}
+
+// Scrive su w le voci una per riga, precedute dalla riga apertura e seguite dalla riga chiusura
+func scriviRacchiuse(w io.Writer, apertura string, voci []string, chiusura string) error {
+	if _, err := fmt.Fprintln(w, apertura); err != nil {
+		return err
+	}
+	for _, v := range voci {
+		if _, err := fmt.Fprintln(w, v); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, chiusura)
+	return err
+}
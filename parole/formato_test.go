@@ -0,0 +1,25 @@
+package parole
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormato(t *testing.T) {
+	var b strings.Builder
+
+	WriteSet(&b, []string{"cane", "gatto"})
+	WriteSet(&b, nil)
+	WriteMatch(&b, "aC", []string{"aa", "ab"})
+	WriteChain(&b, []string{"aa", "aba", "bba"}, nil)
+	WriteChain(&b, nil, ErrNoChain)
+
+	atteso := "[\ncane\ngatto\n]\n" +
+		"[\n]\n" +
+		"aC:[\naa\nab\n]\n" +
+		"(\naa\naba\nbba\n)\n" +
+		"non esiste\n"
+	if b.String() != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", b.String(), atteso)
+	}
+}
@@ -0,0 +1,52 @@
+package parole
+
+import "sort"
+
+// Se w è nel dizionario d restituisce il gruppo che la contiene, in ordine alfabetico:
+// l'insieme massimale delle parole raggiungibili da w con una catena. Restituisce nil altrimenti
+func (d *Dictionary) Group(w string) []string {
+	if !d.HasWord(w) {
+		return nil
+	}
+	gruppo := d.visitaGruppo(w, make(map[string]bool))
+	sort.Strings(gruppo)
+	return gruppo
+}
+
+// Restituisce i gruppi del dizionario d, ciascuno in ordine alfabetico, ordinati per
+// dimensione decrescente e a parità di dimensione per prima parola
+func (d *Dictionary) Groups() [][]string {
+	visitata := make(map[string]bool, len(d.parole))
+	var gruppi [][]string
+	for w := range d.parole {
+		if visitata[w] {
+			continue
+		}
+		gruppo := d.visitaGruppo(w, visitata)
+		sort.Strings(gruppo)
+		gruppi = append(gruppi, gruppo)
+	}
+	sort.Slice(gruppi, func(i, j int) bool {
+		if len(gruppi[i]) != len(gruppi[j]) {
+			return len(gruppi[i]) > len(gruppi[j])
+		}
+		return gruppi[i][0] < gruppi[j][0]
+	})
+	return gruppi
+}
+
+// Restituisce le parole raggiungibili da w con una visita in ampiezza che non attraversa
+// le parole già visitate, segnandole in visitata
+func (d *Dictionary) visitaGruppo(w string, visitata map[string]bool) []string {
+	visitata[w] = true
+	gruppo := []string{w}
+	for i := 0; i < len(gruppo); i++ {
+		for _, v := range d.vicini(gruppo[i]) {
+			if !visitata[v] {
+				visitata[v] = true
+				gruppo = append(gruppo, v)
+			}
+		}
+	}
+	return gruppo
+}
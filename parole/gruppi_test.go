@@ -0,0 +1,49 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestGruppi(t *testing.T) {
+	d := nuovoDizionario(t, "bba", "aa", "aba", "aaa", "cca", "xyz", "xy")
+
+	if got, want := d.Group("aa"), []string{"aa", "aaa", "aba", "bba"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Group(aa) = %v, atteso %v", got, want)
+	}
+	if got := d.Group("zz"); got != nil {
+		t.Errorf("Group(zz) = %v, atteso nil", got)
+	}
+	atteso := [][]string{{"aa", "aaa", "aba", "bba"}, {"xy", "xyz"}, {"cca"}}
+	if got := d.Groups(); !reflect.DeepEqual(got, atteso) {
+		t.Errorf("Groups() = %v, atteso %v", got, atteso)
+	}
+}
+
+func TestGruppiCasuali(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	d := New()
+	parole := paroleCasuali(r, 120, []rune("abcde"))
+	d.Apply(Op{Kind: OpInsert, Entries: parole})
+
+	// Due parole sono nello stesso gruppo se e solo se esiste una catena tra loro
+	gruppo := make(map[string]int)
+	totale := 0
+	for i, g := range d.Groups() {
+		for _, w := range g {
+			gruppo[w] = i
+		}
+		totale += len(g)
+	}
+	if totale != len(d.parole) {
+		t.Fatalf("i gruppi contengono %d parole, attese %d", totale, len(d.parole))
+	}
+	for i := 0; i < 300; i++ {
+		x, y := parole[r.Intn(len(parole))], parole[r.Intn(len(parole))]
+		_, err := d.Chain(x, y)
+		if (err == nil) != (gruppo[x] == gruppo[y]) {
+			t.Fatalf("%s e %s: catena %v, gruppi %d e %d", x, y, err, gruppo[x], gruppo[y])
+		}
+	}
+}
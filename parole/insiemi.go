@@ -0,0 +1,39 @@
+package parole
+
+// Restituisce un nuovo dizionario con le parole e gli schemi presenti in a o in b
+func Union(a, b *Dictionary) *Dictionary {
+	return combina(a, b, func(inA, inB bool) bool { return inA || inB })
+}
+
+// Restituisce un nuovo dizionario con le parole e gli schemi presenti sia in a sia in b
+func Intersection(a, b *Dictionary) *Dictionary {
+	return combina(a, b, func(inA, inB bool) bool { return inA && inB })
+}
+
+// Restituisce un nuovo dizionario con le parole e gli schemi presenti in a ma non in b
+func Difference(a, b *Dictionary) *Dictionary {
+	return combina(a, b, func(inA, inB bool) bool { return inA && !inB })
+}
+
+// Costruisce il dizionario delle voci di a e b per cui tieni restituisce true,
+// operando separatamente sulle parole e sugli schemi. Il risultato è in modalità
+// Unicode se lo è almeno uno tra a e b
+func combina(a, b *Dictionary, tieni func(inA, inB bool) bool) *Dictionary {
+	risultato := New()
+	risultato.unicode = a.unicode || b.unicode
+	for _, sezione := range [][2]map[string]struct{}{{a.parole, b.parole}, {a.schemi, b.schemi}} {
+		var voci []string
+		for _, insieme := range sezione {
+			for w := range insieme {
+				_, inA := sezione[0][w]
+				_, inB := sezione[1][w]
+				if tieni(inA, inB) {
+					voci = append(voci, w)
+				}
+			}
+		}
+		// Le voci comuni compaiono due volte, ma Apply le inserisce una volta sola
+		risultato.Apply(Op{Kind: OpInsert, Entries: voci})
+	}
+	return risultato
+}
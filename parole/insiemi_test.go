@@ -0,0 +1,30 @@
+package parole
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInsiemi(t *testing.T) {
+	a := nuovoDizionario(t, "aa", "bb", "AB", "aB")
+	b := nuovoDizionario(t, "bb", "cc", "aB", "AA")
+
+	casi := []struct {
+		nome   string
+		d      *Dictionary
+		parole []string
+		schemi []string
+	}{
+		{"unione", Union(a, b), []string{"aa", "bb", "cc"}, []string{"AA", "AB", "aB"}},
+		{"intersezione", Intersection(a, b), []string{"bb"}, []string{"aB"}},
+		{"differenza", Difference(a, b), []string{"aa"}, []string{"AB"}},
+	}
+	for _, c := range casi {
+		if got := c.d.Words(); !reflect.DeepEqual(got, c.parole) {
+			t.Errorf("%s: parole %v, attese %v", c.nome, got, c.parole)
+		}
+		if got := c.d.Schemas(); !reflect.DeepEqual(got, c.schemi) {
+			t.Errorf("%s: schemi %v, attesi %v", c.nome, got, c.schemi)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package parole
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Il journal è un file di testo in cui ogni modifica del dizionario è registrata su una riga:
+//
+//	i w1 w2 ...   inserimento delle voci
+//	e w1 w2 ...   eliminazione delle voci
+//	c             svuotamento del dizionario
+//
+// Una riga priva del carattere di a capo finale è una registrazione interrotta da un
+// crash e viene scartata. Riapplicare le operazioni del journal a uno snapshot che già
+// le comprende non cambia il dizionario, quindi un crash durante la compattazione non
+// causa perdite.
+
+// Errore restituito se il journal contiene una registrazione non valida
+var ErrJournalCorrupt = errors.New("parole: journal corrotto")
+
+// Journal delle modifiche di un dizionario aperto in scrittura
+type Journal struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+// Apre il journal nome, creandolo se non esiste, e ne riapplica le registrazioni
+// al dizionario d. Un'eventuale registrazione incompleta in coda viene rimossa
+func OpenJournal(nome string, d *Dictionary) (*Journal, error) {
+	dati, err := os.ReadFile(nome)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	// Scarto la registrazione incompleta dopo l'ultimo a capo
+	valido := bytes.LastIndexByte(dati, '\n') + 1
+	if err := riapplica(d, dati[:valido]); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(nome, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Truncate(int64(valido)); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(int64(valido), 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Journal{f: f, w: bufio.NewWriter(f)}, nil
+}
+
+// Applica al dizionario d le registrazioni contenute in dati
+func riapplica(d *Dictionary, dati []byte) error {
+	for n, riga := range strings.Split(string(dati), "\n") {
+		if riga == "" {
+			continue
+		}
+		op, err := decodificaOp(riga)
+		if err != nil {
+			return fmt.Errorf("%w: riga %d: %v", ErrJournalCorrupt, n+1, err)
+		}
+		d.Apply(op)
+	}
+	return nil
+}
+
+// Decodifica una riga del journal nell'operazione corrispondente
+func decodificaOp(riga string) (Op, error) {
+	campi := strings.Fields(riga)
+	var op Op
+	if len(campi) == 0 {
+		return op, errors.New("riga di soli spazi")
+	}
+	switch campi[0] {
+	case "i":
+		op.Kind = OpInsert
+	case "e":
+		op.Kind = OpDelete
+	case "c":
+		if len(campi) != 1 {
+			return op, errors.New("svuotamento con argomenti")
+		}
+		return Op{Kind: OpReset}, nil
+	default:
+		return op, fmt.Errorf("operazione sconosciuta %q", campi[0])
+	}
+	op.Entries = campi[1:]
+	return op, nil
+}
+
+// Codifica l'operazione op come riga del journal
+func codificaOp(op Op) string {
+	switch op.Kind {
+	case OpInsert:
+		return "i " + strings.Join(op.Entries, " ") + "\n"
+	case OpDelete:
+		return "e " + strings.Join(op.Entries, " ") + "\n"
+	default:
+		return "c\n"
+	}
+}
+
+// Registra l'operazione op in coda al journal. Le operazioni vuote non vengono registrate
+func (j *Journal) Append(op Op) error {
+	if op.Empty() {
+		return nil
+	}
+	if _, err := j.w.WriteString(codificaOp(op)); err != nil {
+		return err
+	}
+	// La registrazione deve arrivare al sistema operativo prima di proseguire
+	return j.w.Flush()
+}
+
+// Compatta il journal: salva lo snapshot del dizionario d nel file snapshot e svuota il journal
+func (j *Journal) Compact(d *Dictionary, snapshot string) error {
+	if err := j.w.Flush(); err != nil {
+		return err
+	}
+	if err := d.SaveSnapshot(snapshot); err != nil {
+		return err
+	}
+	if err := j.f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := j.f.Seek(0, 0)
+	return err
+}
+
+// Chiude il journal
+func (j *Journal) Close() error {
+	if err := j.w.Flush(); err != nil {
+		j.f.Close()
+		return err
+	}
+	return j.f.Close()
+}
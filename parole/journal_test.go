@@ -0,0 +1,132 @@
+package parole
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	dir := t.TempDir()
+	nome := filepath.Join(dir, "journal")
+	snapshot := filepath.Join(dir, "snapshot")
+
+	d := New()
+	j, err := OpenJournal(nome, d)
+	if err != nil {
+		t.Fatalf("OpenJournal: %v", err)
+	}
+	for _, op := range []Op{
+		{Kind: OpReset}, // senza effetto su un dizionario vuoto: non registrato
+		{Kind: OpInsert, Entries: []string{"aa", "bb", "AB"}},
+		{Kind: OpReset},
+		{Kind: OpInsert, Entries: []string{"cc", "dd", "aa"}},
+		{Kind: OpDelete, Entries: []string{"dd", "zz"}},
+	} {
+		if err := j.Append(d.Apply(op)); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	j.Close()
+	if dati, _ := os.ReadFile(nome); !strings.HasPrefix(string(dati), "i aa bb AB\nc\n") {
+		t.Errorf("journal:\n%s", dati)
+	}
+
+	// Simulo un crash durante la scrittura di una registrazione
+	f, _ := os.OpenFile(nome, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString("i ee f")
+	f.Close()
+
+	ripristinato := New()
+	j, err = OpenJournal(nome, ripristinato)
+	if err != nil {
+		t.Fatalf("OpenJournal: %v", err)
+	}
+	if want := []string{"aa", "cc"}; !reflect.DeepEqual(ripristinato.Entries(), want) {
+		t.Errorf("dizionario ripristinato %v, atteso %v", ripristinato.Entries(), want)
+	}
+
+	// Dopo la compattazione lo snapshot contiene tutto e il journal è vuoto
+	j.Append(ripristinato.Apply(Op{Kind: OpInsert, Entries: []string{"ee"}}))
+	if err := j.Compact(ripristinato, snapshot); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	j.Append(ripristinato.Apply(Op{Kind: OpDelete, Entries: []string{"aa"}}))
+	j.Close()
+
+	riaperto := New()
+	if err := riaperto.LoadSnapshot(snapshot); err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if _, err := OpenJournal(nome, riaperto); err != nil {
+		t.Fatalf("OpenJournal: %v", err)
+	}
+	if want := []string{"cc", "ee"}; !reflect.DeepEqual(riaperto.Entries(), want) {
+		t.Errorf("dizionario riaperto %v, atteso %v", riaperto.Entries(), want)
+	}
+}
+
+func TestJournalSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	nome := filepath.Join(dir, "journal")
+	snapshot := filepath.Join(dir, "snapshot")
+	nuovoDizionario(t, "bb", "cc", "AB").SaveSnapshot(snapshot)
+
+	d := nuovoDizionario(t, "aa")
+	j, err := OpenJournal(nome, nuovoDizionario(t, "aa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	effettive, err := d.ReplaceWithSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("ReplaceWithSnapshot: %v", err)
+	}
+	if len(effettive) != 2 || !reflect.DeepEqual(effettive[0].Entries, []string{"aa"}) || len(effettive[1].Entries) != 3 {
+		t.Errorf("operazioni effettive %v", effettive)
+	}
+	for _, op := range effettive {
+		j.Append(op)
+	}
+	j.Append(d.Apply(Op{Kind: OpInsert, Entries: []string{"dd"}}))
+	j.Close()
+
+	// Il journal contiene le operazioni effettive e non dipende più dal file dello snapshot
+	os.Remove(snapshot)
+	riaperto := nuovoDizionario(t, "aa")
+	if _, err := OpenJournal(nome, riaperto); err != nil {
+		t.Fatalf("OpenJournal: %v", err)
+	}
+	if want := []string{"bb", "cc", "dd", "AB"}; !reflect.DeepEqual(riaperto.Entries(), want) {
+		t.Errorf("dizionario riaperto %v, atteso %v", riaperto.Entries(), want)
+	}
+	if !d.Compatible("ab", "AB") || len(d.Match("Ac")) != 1 {
+		t.Errorf("indici del dizionario sostituito non coerenti")
+	}
+
+	if _, err := NewUnicode().ReplaceWithSnapshot(snapshot); err == nil {
+		t.Errorf("ReplaceWithSnapshot di un file rimosso non ha restituito errore")
+	}
+	nuovoDizionario(t, "bb").SaveSnapshot(snapshot)
+	if _, err := NewUnicode().ReplaceWithSnapshot(snapshot); err != ErrUnicodeMode {
+		t.Errorf("ReplaceWithSnapshot in modalità diversa = %v, atteso ErrUnicodeMode", err)
+	}
+}
+
+func TestJournalCorrotto(t *testing.T) {
+	nome := filepath.Join(t.TempDir(), "journal")
+	os.WriteFile(nome, []byte("i aa\nx bb\n"), 0o644)
+	if _, err := OpenJournal(nome, New()); err == nil {
+		t.Errorf("OpenJournal su journal corrotto non ha restituito errore")
+	}
+
+	// Una riga di soli spazi è segnalata come corruzione
+	for _, contenuto := range []string{"i abc\n \n", "i abc\n\t\ne abc\n"} {
+		os.WriteFile(nome, []byte(contenuto), 0o644)
+		if _, err := OpenJournal(nome, New()); !errors.Is(err, ErrJournalCorrupt) {
+			t.Errorf("OpenJournal(%q) = %v, atteso ErrJournalCorrupt", contenuto, err)
+		}
+	}
+}
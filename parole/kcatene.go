@@ -0,0 +1,135 @@
+package parole
+
+import (
+	"sort"
+	"strings"
+)
+
+// Arco orientato tra due parole simili
+type arco struct {
+	da, a string
+}
+
+// Restituisce la prima in ordine lessicografico tra le catene di lunghezza minima da x a y
+// che usano solo i passi u->v per cui ammesso restituisce true, nil se non esiste.
+// Una visita in ampiezza all'indietro da y calcola la distanza da y delle parole; la catena
+// sceglie poi da x, ad ogni passo, la minore delle parole più vicine di uno a y
+func (d *Dictionary) catenaVincolata(x, y string, ammesso func(u, v string) bool) []string {
+	distanza := map[string]int{y: 0}
+	livello := []string{y}
+	for len(livello) > 0 {
+		if _, ok := distanza[x]; ok {
+			break
+		}
+		var prossimo []string
+		for _, v := range livello {
+			for _, u := range d.vicini(v) {
+				if _, ok := distanza[u]; ok || !ammesso(u, v) {
+					continue
+				}
+				distanza[u] = distanza[v] + 1
+				prossimo = append(prossimo, u)
+			}
+		}
+		livello = prossimo
+	}
+	if _, ok := distanza[x]; !ok {
+		return nil
+	}
+
+	catena := []string{x}
+	for u := x; u != y; {
+		prossima := ""
+		for _, v := range d.vicini(u) {
+			if dv, ok := distanza[v]; ok && dv == distanza[u]-1 && ammesso(u, v) && (prossima == "" || v < prossima) {
+				prossima = v
+			}
+		}
+		catena = append(catena, prossima)
+		u = prossima
+	}
+	return catena
+}
+
+// Restituisce le k catene senza parole ripetute più corte tra le parole x e y, in ordine di
+// lunghezza crescente (a parità di lunghezza in ordine lessicografico), con l'algoritmo di Yen.
+// Restituisce ErrNoChain se x o y non sono nel dizionario o se non esiste alcuna catena
+func (d *Dictionary) KShortestChains(x, y string, k int) ([][]string, error) {
+	// La prima catena è la minore in ordine lessicografico tra quelle di lunghezza minima
+	minime, err := d.AllShortestChains(x, y, 1)
+	if err != nil {
+		return nil, err
+	}
+	prima := minime[0]
+	if k <= 0 {
+		return nil, nil
+	}
+
+	trovate := [][]string{prima}
+	var candidate [][]string
+	giaCandidata := map[string]bool{chiaveCatena(prima): true}
+
+	for len(trovate) < k {
+		ultima := trovate[len(trovate)-1]
+		// Ogni parola dell'ultima catena (tranne y) fa da deviazione dopo il prefisso che la precede
+		for i := 0; i < len(ultima)-1; i++ {
+			deviazione := ultima[i]
+			radice := ultima[:i+1]
+
+			// Escludo i passi già usati dalle catene trovate con la stessa radice
+			archiEsclusi := make(map[arco]bool)
+			for _, c := range trovate {
+				if len(c) > i+1 && stessoPrefisso(c, radice) {
+					archiEsclusi[arco{c[i], c[i+1]}] = true
+				}
+			}
+			// Escludo le parole della radice per ottenere catene senza ripetizioni
+			escluse := make(map[string]bool, i)
+			for _, w := range radice[:i] {
+				escluse[w] = true
+			}
+
+			coda := d.catenaVincolata(deviazione, y, func(u, v string) bool {
+				return !escluse[v] && !archiEsclusi[arco{u, v}]
+			})
+			if coda == nil {
+				continue
+			}
+			candidata := append(append([]string(nil), radice[:i]...), coda...)
+			chiave := chiaveCatena(candidata)
+			if !giaCandidata[chiave] {
+				giaCandidata[chiave] = true
+				candidate = append(candidate, candidata)
+			}
+		}
+
+		if len(candidate) == 0 {
+			break
+		}
+		// La prossima catena è la candidata più corta, a parità di lunghezza la minore in ordine lessicografico
+		sort.Slice(candidate, func(a, b int) bool {
+			if len(candidate[a]) != len(candidate[b]) {
+				return len(candidate[a]) < len(candidate[b])
+			}
+			return chiaveCatena(candidate[a]) < chiaveCatena(candidate[b])
+		})
+		trovate = append(trovate, candidate[0])
+		candidate = candidate[1:]
+	}
+	return trovate, nil
+}
+
+// Restituisce true se la catena c inizia con le parole di prefisso
+func stessoPrefisso(c, prefisso []string) bool {
+	for i, w := range prefisso {
+		if c[i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+// Restituisce una chiave che identifica la catena c
+func chiaveCatena(c []string) string {
+	return strings.Join(c, " ")
+}
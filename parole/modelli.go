@@ -0,0 +1,83 @@
+package parole
+
+import "sort"
+
+// Gruppo delle parole con lo stesso modello
+type PatternGroup struct {
+	Pattern string
+	Words   []string // in ordine alfabetico
+}
+
+// Restituisce il modello della stringa w nella modalità del dizionario d: ogni simbolo
+// è sostituito da una lettera maiuscola, A per il primo simbolo distinto, B per il secondo
+// e così via (abba -> ABBA, hello -> ABCCD). Oltre il ventiseiesimo simbolo distinto il
+// modello prosegue con i caratteri successivi a Z
+func (d *Dictionary) Pattern(w string) string {
+	return modello(d.simboli(w))
+}
+
+// Restituisce il modello della sequenza di simboli s
+func modello(s []rune) string {
+	lettera := make(map[rune]rune)
+	m := make([]rune, len(s))
+	for i, c := range s {
+		l, ok := lettera[c]
+		if !ok {
+			l = 'A' + rune(len(lettera))
+			lettera[c] = l
+		}
+		m[i] = l
+	}
+	return string(m)
+}
+
+// Restituisce i gruppi delle parole del dizionario d con lo stesso modello, per numero di
+// parole decrescente e a parità di numero per modello. Se n è positivo restituisce solo
+// gli n gruppi più grandi
+func (d *Dictionary) PatternGroups(n int) []PatternGroup {
+	var gruppi []PatternGroup
+	dimensione := make(map[string]int)
+	for _, modelli := range d.modelli {
+		for m, parole := range modelli {
+			gruppi = append(gruppi, PatternGroup{Pattern: m})
+			dimensione[m] = len(parole)
+		}
+	}
+	sort.Slice(gruppi, func(i, j int) bool {
+		a, b := gruppi[i].Pattern, gruppi[j].Pattern
+		if dimensione[a] != dimensione[b] {
+			return dimensione[a] > dimensione[b]
+		}
+		return a < b
+	})
+	if n > 0 && n < len(gruppi) {
+		gruppi = gruppi[:n]
+	}
+	// Ordino solo le parole dei gruppi restituiti
+	for i, g := range gruppi {
+		gruppi[i].Words = ordinate(d.modelli[len([]rune(g.Pattern))][g.Pattern])
+	}
+	return gruppi
+}
+
+// Restituisce le parole compatibili con lo schema di soli simboli variabili s usando i
+// gruppi per modello: in modalità iniettiva sono le parole con lo stesso modello di s,
+// altrimenti quelle dei gruppi il cui modello, come parola, è compatibile con s
+func (d *Dictionary) compatibiliPerModello(s []rune, modo MatchMode) []string {
+	var risultato []string
+	modelli := d.modelli[len(s)]
+	if modo == MatchInjective {
+		for w := range modelli[modello(s)] {
+			risultato = append(risultato, w)
+		}
+		return risultato
+	}
+	for m, parole := range modelli {
+		if compatibile([]rune(m), s, d.variabile) {
+			for w := range parole {
+				risultato = append(risultato, w)
+			}
+		}
+	}
+	return risultato
+}
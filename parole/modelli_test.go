@@ -0,0 +1,52 @@
+package parole
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModelli(t *testing.T) {
+	d := nuovoDizionario(t, "abba", "otto", "anna", "hello", "aa", "ab", "cd", "ee", "abca")
+
+	casi := []struct{ w, modello string }{
+		{"abba", "ABBA"},
+		{"hello", "ABCCD"},
+		{"a", "A"},
+		{"XYXZ", "ABAC"},
+	}
+	for _, c := range casi {
+		if got := d.Pattern(c.w); got != c.modello {
+			t.Errorf("Pattern(%s) = %s, atteso %s", c.w, got, c.modello)
+		}
+	}
+
+	atteso := []PatternGroup{
+		{"ABBA", []string{"abba", "anna", "otto"}},
+		{"AA", []string{"aa", "ee"}},
+		{"AB", []string{"ab", "cd"}},
+	}
+	if got := d.PatternGroups(3); !reflect.DeepEqual(got, atteso) {
+		t.Errorf("PatternGroups(3) = %v, atteso %v", got, atteso)
+	}
+
+	// La ricerca di uno schema di sole variabili usa i gruppi per modello
+	if got, want := d.Match("XY"), []string{"aa", "ab", "cd", "ee"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Match(XY) = %v, atteso %v", got, want)
+	}
+	if got, want := d.MatchWith("XY", MatchInjective), []string{"ab", "cd"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchWith(XY, MatchInjective) = %v, atteso %v", got, want)
+	}
+	if got, want := d.Match("ABCA"), []string{"abba", "abca", "anna", "otto"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Match(ABCA) = %v, atteso %v", got, want)
+	}
+
+	// I gruppi sono aggiornati dalle eliminazioni
+	d.Delete("otto")
+	d.Delete("hello")
+	if got, want := d.MatchWith("ABBA", MatchInjective), []string{"abba", "anna"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchWith(ABBA, MatchInjective) = %v, atteso %v", got, want)
+	}
+	if got := d.Match("ABCDE"); got != nil {
+		t.Errorf("Match(ABCDE) = %v, atteso nil", got)
+	}
+}
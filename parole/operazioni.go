@@ -0,0 +1,107 @@
+package parole
+
+import "errors"
+
+// Errore restituito se un dizionario è sostituito con uno di modalità Unicode diversa
+var ErrUnicodeMode = errors.New("parole: modalità Unicode diversa da quella del dizionario")
+
+// Tipo di operazione di modifica del dizionario
+type OpKind int
+
+const (
+	OpInsert OpKind = iota // inserimento delle voci
+	OpDelete               // eliminazione delle voci
+	OpReset                // svuotamento del dizionario
+)
+
+// Operazione di modifica del dizionario su un insieme di parole/schemi
+type Op struct {
+	Kind    OpKind
+	Entries []string
+}
+
+// Applica l'operazione op al dizionario d e restituisce l'operazione effettivamente eseguita:
+// per OpInsert e OpDelete le sole voci inserite o eliminate, per OpReset tutte le voci eliminate.
+// Le voci non valide di un OpInsert vengono ignorate
+func (d *Dictionary) Apply(op Op) Op {
+	effettiva := Op{Kind: op.Kind}
+	switch op.Kind {
+	case OpInsert:
+		for _, w := range op.Entries {
+			if !d.has(w) && d.Insert(w) == nil {
+				effettiva.Entries = append(effettiva.Entries, w)
+			}
+		}
+	case OpDelete:
+		for _, w := range op.Entries {
+			if d.Delete(w) {
+				effettiva.Entries = append(effettiva.Entries, w)
+			}
+		}
+	case OpReset:
+		effettiva.Entries = d.Entries()
+		d.Reset()
+	}
+	return effettiva
+}
+
+// Sostituisce il contenuto del dizionario d con quello di nuovo, nella stessa modalità,
+// senza ripeterne la validazione e l'indicizzazione; nuovo non deve più essere usato.
+// Restituisce le operazioni effettive equivalenti: lo svuotamento di d e l'inserimento
+// delle voci di nuovo
+func (d *Dictionary) Replace(nuovo *Dictionary) ([]Op, error) {
+	if nuovo.unicode != d.unicode {
+		return nil, ErrUnicodeMode
+	}
+	ops := []Op{{Kind: OpReset, Entries: d.voci()}, {Kind: OpInsert, Entries: nuovo.voci()}}
+	*d = *nuovo
+	return ops, nil
+}
+
+// Sostituisce il contenuto del dizionario d con lo snapshot contenuto nel file nome, che
+// deve avere la stessa modalità di d. Restituisce le operazioni effettive come Replace
+func (d *Dictionary) ReplaceWithSnapshot(nome string) ([]Op, error) {
+	nuovo := d.NewEmpty()
+	if err := nuovo.LoadSnapshot(nome); err != nil {
+		return nil, err
+	}
+	return d.Replace(nuovo)
+}
+
+// Restituisce true se l'operazione effettiva op, come restituita da Apply, non ha modificato
+// il dizionario: anche uno svuotamento senza voci, eseguito su un dizionario già vuoto
+func (op Op) Empty() bool {
+	return len(op.Entries) == 0
+}
+
+// Restituisce tutte le voci del dizionario d: prima le parole, poi gli schemi
+func (d *Dictionary) Entries() []string {
+	return append(d.Words(), d.Schemas()...)
+}
+
+// Restituisce tutte le voci del dizionario d, in ordine qualsiasi
+func (d *Dictionary) voci() []string {
+	voci := make([]string, 0, len(d.parole)+len(d.schemi))
+	for w := range d.parole {
+		voci = append(voci, w)
+	}
+	for s := range d.schemi {
+		voci = append(voci, s)
+	}
+	return voci
+}
+
+// Verifica l'esistenza della parola/schema w all'interno del dizionario d
+func (d *Dictionary) has(w string) bool {
+	if d.IsSchema(w) {
+		return d.HasSchema(w)
+	}
+	return d.HasWord(w)
+}
+
+// Restituisce una copia indipendente del dizionario d
+func (d *Dictionary) Clone() *Dictionary {
+	copia := d.NewEmpty()
+	copia.Apply(Op{Kind: OpInsert, Entries: d.Entries()})
+	return copia
+}
@@ -0,0 +1,107 @@
+package parole
+
+import "sort"
+
+// Relazione tra due parole consecutive di una catena. Il valore zero è StepEdit.
+// Le relazioni sono simmetriche, come richiesto dalla ricerca bidirezionale
+type Step struct {
+	vicini func(d *Dictionary, w string) []string
+	raggio int // massima distanza di editing tra parole consecutive, 0 se non limitata
+}
+
+// Relazioni di passo predefinite
+var (
+	// Distanza di editing pari a 1 (relazione predefinita)
+	StepEdit = Step{}
+	// Sostituzione di un simbolo: la scala di parole classica, tra parole della stessa lunghezza
+	StepSubstitution = Step{vicini: (*Dictionary).viciniSostituzione, raggio: 1}
+	// Inserimento o cancellazione di un simbolo
+	StepInsertDelete = Step{vicini: (*Dictionary).viciniInserimentoCancellazione, raggio: 1}
+	// Distanza di editing pari a 1 oppure scambio di due simboli adiacenti
+	StepTransposition = Step{vicini: (*Dictionary).viciniTrasposizione, raggio: 2}
+	// Anagramma: permutazione dei simboli della parola
+	StepAnagram = Step{vicini: (*Dictionary).viciniAnagramma}
+)
+
+// Relazione di passo tra parole a distanza di editing compresa tra 1 e k.
+// I vicini sono cercati confrontando la parola con tutto il dizionario
+func StepWithin(k int) Step {
+	s := StepFunc(func(d *Dictionary, a, b string) bool {
+		n := d.Distance(a, b)
+		return n >= 1 && n <= k
+	})
+	s.raggio = k
+	return s
+}
+
+// Relazione di passo definita dal predicato simile, che deve essere simmetrico.
+// I vicini sono cercati confrontando la parola con tutto il dizionario
+func StepFunc(simile func(d *Dictionary, a, b string) bool) Step {
+	return Step{vicini: func(d *Dictionary, w string) []string {
+		var vicini []string
+		for v := range d.parole {
+			if v != w && simile(d, w, v) {
+				vicini = append(vicini, v)
+			}
+		}
+		return vicini
+	}}
+}
+
+// Restituisce le parole del dizionario d in relazione di passo s con la parola w
+func (s Step) neighbors(d *Dictionary, w string) []string {
+	if s.vicini == nil {
+		return d.vicini(w)
+	}
+	return s.vicini(d, w)
+}
+
+// Restituisce un limite inferiore al numero di passi s necessari per andare da una
+// parola di simboli w a una di simboli y: la distanza di editing divisa per la massima
+// distanza di un passo, per eccesso. Se la distanza di un passo non è limitata restituisce 0
+func (s Step) stima(w, y []rune) int {
+	raggio := s.raggio
+	if s.vicini == nil {
+		raggio = 1
+	}
+	if raggio <= 0 {
+		return 0
+	}
+	return (distanza(w, y) + raggio - 1) / raggio
+}
+
+// Restituisce le parole del dizionario d a distanza di editing 1 da w
+// o che si ottengono da w scambiando due simboli adiacenti diversi
+func (d *Dictionary) viciniTrasposizione(w string) []string {
+	vicini := d.vicini(w)
+	s := d.simboli(w)
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == s[i+1] {
+			continue
+		}
+		s[i], s[i+1] = s[i+1], s[i]
+		if v := string(s); d.HasWord(v) {
+			vicini = append(vicini, v)
+		}
+		s[i], s[i+1] = s[i+1], s[i]
+	}
+	return vicini
+}
+
+// Restituisce le parole del dizionario d, diverse da w, che sono anagrammi di w
+func (d *Dictionary) viciniAnagramma(w string) []string {
+	var vicini []string
+	for v := range d.anagrammi[chiaveAnagramma(d.simboli(w))] {
+		if v != w {
+			vicini = append(vicini, v)
+		}
+	}
+	return vicini
+}
+
+// Restituisce la chiave comune a tutti gli anagrammi dei simboli s: i simboli ordinati
+func chiaveAnagramma(s []rune) string {
+	ordinati := append([]rune(nil), s...)
+	sort.Slice(ordinati, func(i, j int) bool { return ordinati[i] < ordinati[j] })
+	return string(ordinati)
+}
@@ -0,0 +1,83 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Restituisce true se b si ottiene da a scambiando due simboli adiacenti diversi
+func scambioAdiacente(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i+1 < len(a); i++ {
+		if a[i] != a[i+1] && a[:i] == b[:i] && a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:] {
+			return true
+		}
+	}
+	return false
+}
+
+func TestPassi(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	d := New()
+	d.Apply(Op{Kind: OpInsert, Entries: paroleCasuali(r, 150, []rune("abc"))})
+
+	casi := []struct {
+		nome   string
+		passo  Step
+		simile func(a, b string) bool
+	}{
+		{"modifica", StepEdit, d.isSimile},
+		{"sostituzione", StepSubstitution, func(a, b string) bool { return len(a) == len(b) && d.isSimile(a, b) }},
+		{"inserimento/cancellazione", StepInsertDelete, func(a, b string) bool { return len(a) != len(b) && d.isSimile(a, b) }},
+		{"trasposizione", StepTransposition, func(a, b string) bool { return d.isSimile(a, b) || scambioAdiacente(a, b) }},
+		{"anagramma", StepAnagram, func(a, b string) bool {
+			return a != b && chiaveAnagramma([]rune(a)) == chiaveAnagramma([]rune(b))
+		}},
+		{"entro 2", StepWithin(2), func(a, b string) bool { n := d.Distance(a, b); return n == 1 || n == 2 }},
+	}
+	for _, c := range casi {
+		for _, w := range d.Words() {
+			got := c.passo.neighbors(d, w)
+			var want []string
+			for _, v := range d.Words() {
+				if c.simile(w, v) {
+					want = append(want, v)
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, want) && len(got)+len(want) > 0 {
+				t.Fatalf("%s: vicini di %s = %v, attesi %v", c.nome, w, got, want)
+			}
+		}
+	}
+}
+
+func TestCatenaConPasso(t *testing.T) {
+	d := nuovoDizionario(t, "cold", "cord", "card", "ward", "warm", "cod", "wod", "word", "abc", "cab", "bca")
+
+	casi := []struct {
+		x, y   string
+		passo  Step
+		atteso []string
+	}{
+		{"cold", "warm", StepSubstitution, []string{"cold", "cord", "card", "ward", "warm"}},
+		{"cold", "cord", StepInsertDelete, []string{"cold", "cod", "cord"}},
+		{"cold", "cord", StepEdit, []string{"cold", "cord"}},
+		{"abc", "cab", StepAnagram, []string{"abc", "cab"}},
+		{"abc", "cab", StepEdit, nil},
+	}
+	for _, c := range casi {
+		risultato, err := d.ChainWith(c.x, c.y, ChainOptions{Step: c.passo})
+		if c.atteso == nil {
+			if err != ErrNoChain {
+				t.Errorf("ChainWith(%s, %s) = %v, atteso ErrNoChain", c.x, c.y, risultato.Chain)
+			}
+		} else if len(risultato.Chain) != len(c.atteso) {
+			t.Errorf("ChainWith(%s, %s) = %v, %v, atteso %v", c.x, c.y, risultato.Chain, err, c.atteso)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package parole
+
+import (
+	"container/heap"
+	"errors"
+)
+
+// Modello dei costi delle modifiche elementari tra parole consecutive di una catena
+type CostModel struct {
+	Substitution int // costo della sostituzione di un simbolo
+	Insertion    int // costo dell'inserimento di un simbolo
+	Deletion     int // costo della cancellazione di un simbolo
+	// Costo aggiuntivo per simbolo: si applica al simbolo inserito, a quello cancellato
+	// e al nuovo simbolo di una sostituzione
+	Letter map[rune]int
+}
+
+// Modello in cui ogni modifica costa 1: il costo di una catena è il numero dei suoi passi
+var DefaultCostModel = CostModel{Substitution: 1, Insertion: 1, Deletion: 1}
+
+// Errore restituito se il modello dei costi contiene un costo negativo
+var ErrNegativeCost = errors.New("parole: costo negativo nel modello dei costi")
+
+// Restituisce il costo del passo da u a v, parole a distanza di editing 1
+func (c CostModel) costo(u, v []rune) int {
+	// Prima posizione in cui u e v differiscono
+	i := 0
+	for i < len(u) && i < len(v) && u[i] == v[i] {
+		i++
+	}
+	switch {
+	case len(v) > len(u):
+		return c.Insertion + c.Letter[v[i]]
+	case len(v) < len(u):
+		return c.Deletion + c.Letter[u[i]]
+	default:
+		return c.Substitution + c.Letter[v[i]]
+	}
+}
+
+// Restituisce true se nessun costo del modello è negativo
+func (c CostModel) valido() bool {
+	if c.Substitution < 0 || c.Insertion < 0 || c.Deletion < 0 {
+		return false
+	}
+	for _, n := range c.Letter {
+		if n < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Se esiste, restituisce una catena di costo minimo tra le parole x e y del dizionario d
+// secondo il modello dei costi costi, con il suo costo totale (algoritmo di Dijkstra).
+// Restituisce ErrNoChain se x o y non sono nel dizionario o se la catena non esiste
+func (d *Dictionary) WeightedChain(x, y string, costi CostModel) ([]string, int, error) {
+	if !costi.valido() {
+		return nil, 0, ErrNegativeCost
+	}
+	if !d.HasWord(x) || !d.HasWord(y) {
+		return nil, 0, ErrNoChain
+	}
+
+	distanza := map[string]int{x: 0}
+	predecessore := make(map[string]string)
+	definitiva := make(map[string]bool)
+	coda := &codaPriorita{{parola: x}}
+
+	for coda.Len() > 0 {
+		u := heap.Pop(coda).(elementoCoda)
+		if definitiva[u.parola] {
+			continue
+		}
+		definitiva[u.parola] = true
+		if u.parola == y {
+			return ricostruisciCatena(predecessore, x, y), u.priorita, nil
+		}
+
+		su := d.simboli(u.parola)
+		for _, v := range d.vicini(u.parola) {
+			if definitiva[v] {
+				continue
+			}
+			nuova := u.priorita + costi.costo(su, d.simboli(v))
+			if dv, ok := distanza[v]; !ok || nuova < dv {
+				distanza[v] = nuova
+				predecessore[v] = u.parola
+				heap.Push(coda, elementoCoda{parola: v, priorita: nuova})
+			}
+		}
+	}
+	return nil, 0, ErrNoChain
+}
+
+// Parola in coda con la sua priorità
+type elementoCoda struct {
+	parola     string
+	priorita   int
+	profondita int // passi dall'origine, usati dalla ricerca A* a parità di priorità
+}
+
+// Coda di priorità minima delle parole: a parità di priorità prima le più profonde,
+// poi in ordine alfabetico
+type codaPriorita []elementoCoda
+
+func (c codaPriorita) Len() int { return len(c) }
+
+func (c codaPriorita) Less(i, j int) bool {
+	if c[i].priorita != c[j].priorita {
+		return c[i].priorita < c[j].priorita
+	}
+	if c[i].profondita != c[j].profondita {
+		return c[i].profondita > c[j].profondita
+	}
+	return c[i].parola < c[j].parola
+}
+
+func (c codaPriorita) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+
+func (c *codaPriorita) Push(x interface{}) { *c = append(*c, x.(elementoCoda)) }
+
+func (c *codaPriorita) Pop() interface{} {
+	vecchia := *c
+	x := vecchia[len(vecchia)-1]
+	*c = vecchia[:len(vecchia)-1]
+	return x
+}
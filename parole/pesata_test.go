@@ -0,0 +1,60 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestCatenaPesata(t *testing.T) {
+	d := nuovoDizionario(t, "aa", "ab", "bb", "aab", "abb", "cc")
+
+	casi := []struct {
+		costi  CostModel
+		catena []string
+		costo  int
+	}{
+		{DefaultCostModel, []string{"aa", "ab", "bb"}, 2},
+		// Sostituzioni costose: conviene inserire e cancellare
+		{CostModel{Substitution: 5, Insertion: 1, Deletion: 1}, []string{"aa", "aab", "ab", "abb", "bb"}, 4},
+		// Il simbolo b costa 10 sia sostituito sia inserito
+		{CostModel{Substitution: 1, Insertion: 1, Deletion: 1, Letter: map[rune]int{'b': 10}}, []string{"aa", "ab", "bb"}, 22},
+	}
+	for _, c := range casi {
+		catena, costo, err := d.WeightedChain("aa", "bb", c.costi)
+		if err != nil || costo != c.costo || !reflect.DeepEqual(catena, c.catena) {
+			t.Errorf("WeightedChain(aa, bb, %v) = %v, %d, %v, atteso %v, %d", c.costi, catena, costo, err, c.catena, c.costo)
+		}
+	}
+
+	if _, _, err := d.WeightedChain("aa", "cc", DefaultCostModel); err != ErrNoChain {
+		t.Errorf("WeightedChain(aa, cc) = %v, atteso ErrNoChain", err)
+	}
+	if _, _, err := d.WeightedChain("aa", "bb", CostModel{Substitution: -1}); err != ErrNegativeCost {
+		t.Errorf("WeightedChain con costo negativo = %v, atteso ErrNegativeCost", err)
+	}
+}
+
+func TestCatenaPesataUnitaria(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	d := New()
+	parole := paroleCasuali(r, 150, []rune("abcd"))
+	d.Apply(Op{Kind: OpInsert, Entries: parole})
+
+	// Con costi unitari il costo minimo è la lunghezza minima della catena
+	for i := 0; i < 200; i++ {
+		x, y := parole[r.Intn(len(parole))], parole[r.Intn(len(parole))]
+		minima, errMinima := d.Chain(x, y)
+		pesata, costo, err := d.WeightedChain(x, y, DefaultCostModel)
+		if err != errMinima {
+			t.Fatalf("%s-%s: errori diversi %v e %v", x, y, errMinima, err)
+		}
+		if err != nil {
+			continue
+		}
+		controllaCatena(t, d, pesata, x, y)
+		if costo != len(pesata)-1 || len(pesata) != len(minima) {
+			t.Fatalf("%s-%s: catena pesata %v di costo %d, minima %v", x, y, pesata, costo, minima)
+		}
+	}
+}
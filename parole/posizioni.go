@@ -0,0 +1,105 @@
+package parole
+
+import "sort"
+
+// Indice delle parole per la ricerca per schema: le parole sono raggruppate per
+// lunghezza in simboli e, per ogni lunghezza, per simbolo in ciascuna posizione.
+// Le parole compatibili con uno schema hanno la sua lunghezza e i suoi simboli fissi
+// nelle stesse posizioni: sono nell'intersezione delle liste dei simboli fissi dello
+// schema, o tra tutte quelle della sua lunghezza se non ne ha. Delle parole
+// dell'intersezione resta da verificare la coerenza delle variabili
+type indicePosizioni struct {
+	liste map[chiavePosizione]map[string]struct{}
+}
+
+// Simbolo in una posizione delle parole di una lunghezza. Con posizione -1 e
+// simbolo 0 indica tutte le parole della lunghezza
+type chiavePosizione struct {
+	lunghezza int32
+	posizione int32
+	simbolo   rune
+}
+
+// Crea un indice vuoto
+func nuovoIndicePosizioni() *indicePosizioni {
+	return &indicePosizioni{liste: make(map[chiavePosizione]map[string]struct{})}
+}
+
+// Restituisce la chiave della lista delle parole di lunghezza n
+func chiaveLunghezza(n int) chiavePosizione {
+	return chiavePosizione{lunghezza: int32(n), posizione: -1}
+}
+
+// Registra nell'indice la parola w, di simboli s
+func (x *indicePosizioni) aggiungi(w string, s []rune) {
+	x.aggiungiA(chiaveLunghezza(len(s)), w)
+	for i, c := range s {
+		x.aggiungiA(chiavePosizione{int32(len(s)), int32(i), c}, w)
+	}
+}
+
+// Rimuove dall'indice la parola w, di simboli s
+func (x *indicePosizioni) rimuovi(w string, s []rune) {
+	x.rimuoviDa(chiaveLunghezza(len(s)), w)
+	for i, c := range s {
+		x.rimuoviDa(chiavePosizione{int32(len(s)), int32(i), c}, w)
+	}
+}
+
+// Restituisce le parole candidate per lo schema di simboli s: quelle della sua lunghezza
+// con i suoi simboli fissi nelle stesse posizioni, da verificare con compatibile.
+// L'intersezione delle liste dei simboli fissi scorre la più corta e cerca le sue
+// parole nelle altre, dalla più corta alla più lunga
+func (x *indicePosizioni) candidate(s []rune, variabile func(rune) bool) []string {
+	var liste []map[string]struct{}
+	for i, c := range s {
+		if variabile(c) {
+			continue
+		}
+		lista := x.liste[chiavePosizione{int32(len(s)), int32(i), c}]
+		if len(lista) == 0 {
+			return nil
+		}
+		liste = append(liste, lista)
+	}
+	if len(liste) == 0 {
+		liste = append(liste, x.liste[chiaveLunghezza(len(s))])
+	}
+	sort.Slice(liste, func(i, j int) bool { return len(liste[i]) < len(liste[j]) })
+
+	var candidate []string
+	for w := range liste[0] {
+		if inTutte(w, liste[1:]) {
+			candidate = append(candidate, w)
+		}
+	}
+	return candidate
+}
+
+// Restituisce true se w appartiene a tutte le liste
+func inTutte(w string, liste []map[string]struct{}) bool {
+	for _, lista := range liste {
+		if _, ok := lista[w]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Aggiunge w alla lista di chiave k, creandola se necessario
+func (x *indicePosizioni) aggiungiA(k chiavePosizione, w string) {
+	lista, ok := x.liste[k]
+	if !ok {
+		lista = make(map[string]struct{})
+		x.liste[k] = lista
+	}
+	lista[w] = struct{}{}
+}
+
+// Rimuove w dalla lista di chiave k, eliminando la lista se resta vuota
+func (x *indicePosizioni) rimuoviDa(k chiavePosizione, w string) {
+	delete(x.liste[k], w)
+	if len(x.liste[k]) == 0 {
+		delete(x.liste, k)
+	}
+}
@@ -0,0 +1,63 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Confronta le parole trovate da MatchWith con quelle trovate verificando ogni parola
+func controllaRicerca(t *testing.T, d *Dictionary, schemi []string) {
+	t.Helper()
+	for _, schema := range schemi {
+		for _, modo := range []MatchMode{MatchDefault, MatchInjective} {
+			var want []string
+			for w := range d.parole {
+				if d.CompatibleWith(w, schema, modo) {
+					want = append(want, w)
+				}
+			}
+			sort.Strings(want)
+			if got := d.MatchWith(schema, modo); !reflect.DeepEqual(got, want) {
+				t.Fatalf("MatchWith(%s, %d) = %v, attese %v", schema, modo, got, want)
+			}
+		}
+	}
+}
+
+func TestIndicePosizioni(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	for _, d := range []*Dictionary{New(), NewUnicode()} {
+		alfabeto, schemi := []rune("abc"), []rune("abcXY")
+		if d.Unicode() {
+			alfabeto, schemi = []rune("aèò"), []rune("aèÀÒ")
+		}
+		d.Apply(Op{Kind: OpInsert, Entries: paroleCasuali(r, 80, alfabeto)})
+		controllaRicerca(t, d, paroleCasuali(r, 100, schemi))
+
+		// L'indice resta coerente dopo le eliminazioni e lo svuotamento
+		d.Apply(Op{Kind: OpDelete, Entries: paroleCasuali(r, 80, alfabeto)})
+		controllaRicerca(t, d, paroleCasuali(r, 100, schemi))
+		d.Reset()
+		controllaRicerca(t, d, paroleCasuali(r, 10, schemi))
+	}
+}
+
+func TestCandidatePosizioni(t *testing.T) {
+	d := nuovoDizionario(t, "abc", "abd", "aac", "bbc", "abcd", "xbc")
+	// Solo le parole con tutti i simboli fissi: non l'intera lista più corta
+	for schema, attese := range map[string][]string{
+		"aXc": {"aac", "abc"},
+		"XbX": {"abc", "abd", "bbc", "xbc"},
+		"abX": {"abc", "abd"},
+		"XYZ": {"aac", "abc", "abd", "bbc", "xbc"},
+		"zXc": nil,
+	} {
+		candidate := d.posizioni.candidate(d.simboli(schema), d.variabile)
+		sort.Strings(candidate)
+		if !reflect.DeepEqual(candidate, attese) {
+			t.Errorf("candidate(%s) = %v, attese %v", schema, candidate, attese)
+		}
+	}
+}
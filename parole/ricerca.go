@@ -0,0 +1,129 @@
+package parole
+
+import "sort"
+
+// Modalità di compatibilità tra parole e schemi
+type MatchMode int
+
+const (
+	MatchDefault   MatchMode = iota // variabili diverse possono assumere lo stesso simbolo (predefinita)
+	MatchInjective                  // come in un crittogramma: variabili diverse assumono simboli diversi e non fissi nello schema
+)
+
+// Restituisce true se la parola parola è compatibile con lo schema schema, false altrimenti.
+// variabile indica quali simboli dello schema sono variabili
+func compatibile(parola, schema []rune, variabile func(rune) bool) bool {
+	if len(parola) != len(schema) {
+		return false
+	}
+
+	mappa := make(map[rune]rune)
+
+	for i, c := range schema {
+		p := parola[i]
+
+		if variabile(c) {
+			val, esiste := mappa[c]
+			if esiste {
+				if val != p {
+					return false
+				}
+			} else {
+				mappa[c] = p
+			}
+		} else {
+			if c != p {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Restituisce true se la parola è compatibile con lo schema e l'assegnazione delle variabili
+// è iniettiva: variabili diverse assumono simboli diversi, e nessuna variabile assume
+// un simbolo che compare fisso nello schema
+func compatibileIniettivo(parola, schema []rune, variabile func(rune) bool) bool {
+	if !compatibile(parola, schema, variabile) {
+		return false
+	}
+
+	fisso := make(map[rune]bool)
+	for _, c := range schema {
+		if !variabile(c) {
+			fisso[c] = true
+		}
+	}
+	inversa := make(map[rune]rune)
+	for i, c := range schema {
+		if !variabile(c) {
+			continue
+		}
+		p := parola[i]
+		if fisso[p] {
+			return false
+		}
+		if v, esiste := inversa[p]; esiste && v != c {
+			return false
+		}
+		inversa[p] = c
+	}
+	return true
+}
+
+// Restituisce la funzione di compatibilità della modalità modo
+func (modo MatchMode) verifica() func(parola, schema []rune, variabile func(rune) bool) bool {
+	if modo == MatchInjective {
+		return compatibileIniettivo
+	}
+	return compatibile
+}
+
+// Restituisce true se la parola w è compatibile con lo schema schema
+func (d *Dictionary) Compatible(w, schema string) bool {
+	return d.CompatibleWith(w, schema, MatchDefault)
+}
+
+// Come Compatible, nella modalità di compatibilità modo
+func (d *Dictionary) CompatibleWith(w, schema string, modo MatchMode) bool {
+	return modo.verifica()(d.simboli(w), d.simboli(schema), d.variabile)
+}
+
+// Restituisce, in ordine alfabetico, le parole del dizionario d compatibili con lo schema schema.
+// Se lo schema ha solo simboli variabili le parole sono ricavate dai gruppi per modello,
+// altrimenti è verificata solo la coerenza delle variabili nelle parole che hanno i simboli
+// fissi dello schema, ricavate dall'indice per lunghezza e posizione dei simboli
+func (d *Dictionary) Match(schema string) []string {
+	return d.MatchWith(schema, MatchDefault)
+}
+
+// Come Match, nella modalità di compatibilità modo
+func (d *Dictionary) MatchWith(schema string, modo MatchMode) []string {
+	s := d.simboli(schema)
+	if d.soloVariabili(s) {
+		risultato := d.compatibiliPerModello(s, modo)
+		sort.Strings(risultato)
+		return risultato
+	}
+
+	var risultato []string
+	verifica := modo.verifica()
+	for _, parola := range d.posizioni.candidate(s, d.variabile) {
+		if verifica(d.simboli(parola), s, d.variabile) {
+			risultato = append(risultato, parola)
+		}
+	}
+	sort.Strings(risultato)
+	return risultato
+}
+
+// Restituisce true se tutti i simboli di s sono variabili nella modalità del dizionario d
+func (d *Dictionary) soloVariabili(s []rune) bool {
+	for _, c := range s {
+		if !d.variabile(c) {
+			return false
+		}
+	}
+	return true
+}
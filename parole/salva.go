@@ -0,0 +1,65 @@
+package parole
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+)
+
+// Salva sul file nome le parole e gli schemi del dizionario d, una voce per riga,
+// in un formato rileggibile con Load. La scrittura è atomica: il contenuto viene
+// scritto su un file temporaneo che sostituisce nome solo a scrittura completata
+func (d *Dictionary) Save(nome string) error {
+	return scriviAtomico(nome, func(w *bufio.Writer) error {
+		for _, voci := range [][]string{d.Words(), d.Schemas()} {
+			for _, v := range voci {
+				if _, err := w.WriteString(v + "\n"); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Scrive il file nome tramite la funzione scrivi passando per un file temporaneo
+// nella stessa directory, poi rinominato su nome. In caso di errore nome resta invariato
+func scriviAtomico(nome string, scrivi func(w *bufio.Writer) error) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(nome), "."+filepath.Base(nome)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// in caso di errore elimino il file temporaneo
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	// CreateTemp crea il file con permessi 0600: conservo quelli del file sostituito,
+	// o uso 0644 per un file nuovo
+	permessi := os.FileMode(0o644)
+	if info, errStat := os.Stat(nome); errStat == nil {
+		permessi = info.Mode().Perm()
+	}
+	if err = tmp.Chmod(permessi); err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(tmp)
+	if err = scrivi(w); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	// Il contenuto deve essere su disco prima della rinomina
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), nome)
+}
@@ -0,0 +1,128 @@
+package parole
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Indice degli schemi per la ricerca inversa, dalla parola agli schemi compatibili.
+// Gli schemi sono raggruppati per forma, cioè per lunghezza in simboli e insieme delle
+// posizioni dei simboli fissi, e in ogni forma per proiezione, la sequenza dei simboli
+// fissi. Gli schemi compatibili con una parola w hanno la lunghezza di w e, nelle
+// posizioni fisse, i simboli di w: per ogni forma della lunghezza di w basta proiettare
+// w sulle posizioni fisse e verificare gli schemi con quella proiezione
+type indiceSchemi struct {
+	perLunghezza map[int][]*forma
+	perChiave    map[string]*forma
+}
+
+// Schemi di una stessa forma
+type forma struct {
+	chiave string
+	fisse  []int                          // posizioni dei simboli fissi
+	schemi map[string]map[string]struct{} // schemi per proiezione
+	n      int                            // numero di schemi della forma
+}
+
+// Crea un indice vuoto
+func nuovoIndiceSchemi() *indiceSchemi {
+	return &indiceSchemi{
+		perLunghezza: make(map[int][]*forma),
+		perChiave:    make(map[string]*forma),
+	}
+}
+
+// Restituisce la chiave della forma e la proiezione dello schema di simboli s
+func chiaviForma(s []rune, variabile func(rune) bool) (chiave, proiezione string) {
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(s)))
+	var p []rune
+	for i, c := range s {
+		if !variabile(c) {
+			b.WriteByte(' ')
+			b.WriteString(strconv.Itoa(i))
+			p = append(p, c)
+		}
+	}
+	return b.String(), string(p)
+}
+
+// Registra nell'indice lo schema schema, di simboli s
+func (x *indiceSchemi) aggiungi(schema string, s []rune, variabile func(rune) bool) {
+	chiave, proiezione := chiaviForma(s, variabile)
+	f, ok := x.perChiave[chiave]
+	if !ok {
+		f = &forma{chiave: chiave, schemi: make(map[string]map[string]struct{})}
+		for i, c := range s {
+			if !variabile(c) {
+				f.fisse = append(f.fisse, i)
+			}
+		}
+		x.perChiave[chiave] = f
+		x.perLunghezza[len(s)] = append(x.perLunghezza[len(s)], f)
+	}
+	if f.schemi[proiezione] == nil {
+		f.schemi[proiezione] = make(map[string]struct{})
+	}
+	f.schemi[proiezione][schema] = struct{}{}
+	f.n++
+}
+
+// Rimuove dall'indice lo schema schema, di simboli s
+func (x *indiceSchemi) rimuovi(schema string, s []rune, variabile func(rune) bool) {
+	chiave, proiezione := chiaviForma(s, variabile)
+	f := x.perChiave[chiave]
+	delete(f.schemi[proiezione], schema)
+	if len(f.schemi[proiezione]) == 0 {
+		delete(f.schemi, proiezione)
+	}
+	f.n--
+	if f.n > 0 {
+		return
+	}
+	delete(x.perChiave, chiave)
+	forme := x.perLunghezza[len(s)]
+	for i, g := range forme {
+		if g == f {
+			forme[i] = forme[len(forme)-1]
+			forme = forme[:len(forme)-1]
+			break
+		}
+	}
+	if len(forme) == 0 {
+		delete(x.perLunghezza, len(s))
+	} else {
+		x.perLunghezza[len(s)] = forme
+	}
+}
+
+// Restituisce gli schemi candidati per la parola di simboli w: quelli della lunghezza
+// di w con i simboli di w nelle posizioni fisse, da verificare con compatibile
+func (x *indiceSchemi) candidati(w []rune) []string {
+	var candidati []string
+	proiezione := make([]rune, 0, len(w))
+	for _, f := range x.perLunghezza[len(w)] {
+		proiezione = proiezione[:0]
+		for _, i := range f.fisse {
+			proiezione = append(proiezione, w[i])
+		}
+		for schema := range f.schemi[string(proiezione)] {
+			candidati = append(candidati, schema)
+		}
+	}
+	return candidati
+}
+
+// Restituisce, in ordine alfabetico, gli schemi del dizionario d compatibili con la parola w
+func (d *Dictionary) MatchingSchemas(w string) []string {
+	var risultato []string
+	s := d.simboli(w)
+	for _, schema := range d.forme.candidati(s) {
+		if compatibile(s, d.simboli(schema), d.variabile) {
+			risultato = append(risultato, schema)
+		}
+	}
+	sort.Strings(risultato)
+	return risultato
+}
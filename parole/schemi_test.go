@@ -0,0 +1,63 @@
+package parole
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Confronta gli schemi trovati da MatchingSchemas con quelli trovati verificando ogni schema
+func controllaSchemi(t *testing.T, d *Dictionary, parole []string) {
+	t.Helper()
+	for _, w := range parole {
+		var want []string
+		for s := range d.schemi {
+			if d.Compatible(w, s) {
+				want = append(want, s)
+			}
+		}
+		sort.Strings(want)
+		if got := d.MatchingSchemas(w); !reflect.DeepEqual(got, want) {
+			t.Fatalf("MatchingSchemas(%s) = %v, attesi %v", w, got, want)
+		}
+	}
+}
+
+func TestSchemiCompatibili(t *testing.T) {
+	d := nuovoDizionario(t, "aC", "Ab", "AA", "Ba", "ABBA", "aBBa", "ab")
+	if got, want := d.MatchingSchemas("ab"), []string{"Ab", "aC"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchingSchemas(ab) = %v, atteso %v", got, want)
+	}
+	if got, want := d.MatchingSchemas("abba"), []string{"ABBA", "aBBa"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchingSchemas(abba) = %v, atteso %v", got, want)
+	}
+	if got := d.MatchingSchemas("abc"); got != nil {
+		t.Errorf("MatchingSchemas(abc) = %v, atteso nil", got)
+	}
+
+	r := rand.New(rand.NewSource(17))
+	for _, d := range []*Dictionary{New(), NewUnicode()} {
+		alfabeto, simboli := []rune("ab"), []rune("abXY")
+		if d.Unicode() {
+			alfabeto, simboli = []rune("aè"), []rune("aèÀÒ")
+		}
+		schemi := paroleCasuali(r, 120, simboli)
+		d.Apply(Op{Kind: OpInsert, Entries: schemi})
+		controllaSchemi(t, d, paroleCasuali(r, 50, alfabeto))
+
+		// L'indice resta coerente dopo le eliminazioni e la rilettura dello snapshot
+		d.Apply(Op{Kind: OpDelete, Entries: schemi[:60]})
+		controllaSchemi(t, d, paroleCasuali(r, 50, alfabeto))
+		var buf bytes.Buffer
+		if err := d.WriteSnapshot(&buf); err != nil {
+			t.Fatal(err)
+		}
+		riletto := New()
+		if err := riletto.ReadSnapshot(&buf); err != nil {
+			t.Fatal(err)
+		}
+		controllaSchemi(t, riletto, paroleCasuali(r, 50, alfabeto))
+	}
+}
@@ -0,0 +1,208 @@
+package parole
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"unicode"
+)
+
+// Formato binario dello snapshot (interi little endian):
+//
+//	magic    4 byte "PRLS"
+//	versione uint16
+//	flag     uint16 (bit 0: modalità Unicode, gli altri riservati a 0)
+//	parole   uvarint numero voci, poi per ogni voce uvarint lunghezza e byte
+//	schemi   come parole
+//	checksum uint32 CRC-32 (IEEE) di tutti i byte precedenti
+const (
+	snapshotMagic    = "PRLS"
+	snapshotVersione = 1
+
+	flagUnicode = 1 << 0
+)
+
+// Errori restituiti dalla lettura di uno snapshot
+var (
+	ErrSnapshotFormat  = errors.New("parole: il file non è uno snapshot")
+	ErrSnapshotVersion = errors.New("parole: versione dello snapshot non supportata")
+	ErrSnapshotCorrupt = errors.New("parole: snapshot corrotto")
+)
+
+// Scrive su w lo snapshot binario del dizionario d
+func (d *Dictionary) WriteSnapshot(w io.Writer) error {
+	crc := crc32.NewIEEE()
+	bw := bufio.NewWriter(io.MultiWriter(w, crc))
+
+	var intestazione [8]byte
+	copy(intestazione[:4], snapshotMagic)
+	binary.LittleEndian.PutUint16(intestazione[4:], snapshotVersione)
+	if d.unicode {
+		binary.LittleEndian.PutUint16(intestazione[6:], flagUnicode)
+	}
+	if _, err := bw.Write(intestazione[:]); err != nil {
+		return err
+	}
+	for _, sezione := range []map[string]struct{}{d.parole, d.schemi} {
+		if err := scriviSezione(bw, sezione); err != nil {
+			return err
+		}
+	}
+	// Il checksum va calcolato su tutto ciò che precede, quindi svuoto il buffer prima di leggerlo
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	var somma [4]byte
+	binary.LittleEndian.PutUint32(somma[:], crc.Sum32())
+	_, err := w.Write(somma[:])
+	return err
+}
+
+// Scrive il numero di voci della sezione seguito dalle voci con prefisso di lunghezza,
+// in ordine alfabetico perché lo stesso dizionario dia sempre lo stesso snapshot
+func scriviSezione(w *bufio.Writer, sezione map[string]struct{}) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(sezione)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	for _, v := range ordinate(sezione) {
+		n = binary.PutUvarint(buf[:], uint64(len(v)))
+		if _, err := w.Write(buf[:n]); err != nil {
+			return err
+		}
+		if _, err := w.WriteString(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sostituisce il contenuto del dizionario d con lo snapshot letto da r,
+// adottandone anche la modalità. In caso di errore d resta invariato
+func (d *Dictionary) ReadSnapshot(r io.Reader) error {
+	dati, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	if len(dati) < 8 || string(dati[:4]) != snapshotMagic {
+		return ErrSnapshotFormat
+	}
+	if v := binary.LittleEndian.Uint16(dati[4:]); v != snapshotVersione {
+		return fmt.Errorf("%w: %d", ErrSnapshotVersion, v)
+	}
+	flag := binary.LittleEndian.Uint16(dati[6:])
+	if flag&^flagUnicode != 0 {
+		return fmt.Errorf("%w: flag sconosciuti %#x", ErrSnapshotVersion, flag)
+	}
+	if len(dati) < 12 {
+		return fmt.Errorf("%w: checksum mancante", ErrSnapshotCorrupt)
+	}
+	// Verifico il checksum prima di decodificare le sezioni
+	corpo, somma := dati[:len(dati)-4], dati[len(dati)-4:]
+	if binary.LittleEndian.Uint32(somma) != crc32.ChecksumIEEE(corpo) {
+		return fmt.Errorf("%w: checksum errato", ErrSnapshotCorrupt)
+	}
+
+	corpo = corpo[8:]
+	parole, corpo, err := leggiSezione(corpo)
+	if err != nil {
+		return err
+	}
+	schemi, corpo, err := leggiSezione(corpo)
+	if err != nil {
+		return err
+	}
+	if len(corpo) != 0 {
+		return fmt.Errorf("%w: %d byte in eccesso", ErrSnapshotCorrupt, len(corpo))
+	}
+	// Le voci devono essere parole e schemi validi nella modalità dello snapshot
+	modoUnicode := flag&flagUnicode != 0
+	for w := range parole {
+		if valida, schema := classificaVoce(w, modoUnicode); !valida || schema {
+			return fmt.Errorf("%w: parola non valida %q", ErrSnapshotCorrupt, w)
+		}
+	}
+	for w := range schemi {
+		if valida, schema := classificaVoce(w, modoUnicode); !valida || !schema {
+			return fmt.Errorf("%w: schema non valido %q", ErrSnapshotCorrupt, w)
+		}
+	}
+
+	d.parole = parole
+	d.schemi = schemi
+	d.unicode = modoUnicode
+	d.ricostruisciIndici()
+	return nil
+}
+
+// Restituisce se la voce w è valida nella modalità indicata e se è uno schema, con una sola
+// scansione dei byte (delle rune in modalità Unicode) invece dell'espressione regolare di IsValid
+func classificaVoce(w string, modoUnicode bool) (valida, schema bool) {
+	if w == "" {
+		return false, false
+	}
+	if !modoUnicode {
+		for i := 0; i < len(w); i++ {
+			switch c := w[i]; {
+			case c >= 'a' && c <= 'z':
+			case isMaiuscola(rune(c)):
+				schema = true
+			default:
+				return false, false
+			}
+		}
+		return true, schema
+	}
+	for _, c := range w {
+		if !unicode.IsLetter(c) {
+			return false, false
+		}
+		if unicode.IsUpper(c) {
+			schema = true
+		}
+	}
+	return true, schema
+}
+
+// Decodifica una sezione all'inizio di dati, restituendone le voci e i byte rimanenti
+func leggiSezione(dati []byte) (map[string]struct{}, []byte, error) {
+	n, k := binary.Uvarint(dati)
+	if k <= 0 || n > uint64(len(dati)) {
+		return nil, nil, fmt.Errorf("%w: numero di voci non valido", ErrSnapshotCorrupt)
+	}
+	dati = dati[k:]
+
+	sezione := make(map[string]struct{}, n)
+	for i := uint64(0); i < n; i++ {
+		l, k := binary.Uvarint(dati)
+		if k <= 0 || l == 0 || l > uint64(len(dati)-k) {
+			return nil, nil, fmt.Errorf("%w: voce troncata", ErrSnapshotCorrupt)
+		}
+		sezione[string(dati[k:k+int(l)])] = struct{}{}
+		dati = dati[k+int(l):]
+	}
+	return sezione, dati, nil
+}
+
+// Salva atomicamente sul file nome lo snapshot binario del dizionario d
+func (d *Dictionary) SaveSnapshot(nome string) error {
+	return scriviAtomico(nome, func(w *bufio.Writer) error {
+		return d.WriteSnapshot(w)
+	})
+}
+
+// Sostituisce il contenuto del dizionario d con lo snapshot contenuto nel file nome
+func (d *Dictionary) LoadSnapshot(nome string) error {
+	f, err := os.Open(nome)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return d.ReadSnapshot(f)
+}
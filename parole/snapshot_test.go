@@ -0,0 +1,129 @@
+package parole
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	d := nuovoDizionario(t, "abba", "abca", "aa", "ABCa", "aB")
+	nome := filepath.Join(t.TempDir(), "dizionario.snap")
+	if err := d.SaveSnapshot(nome); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	riletto := nuovoDizionario(t, "zzz")
+	if err := riletto.LoadSnapshot(nome); err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if !reflect.DeepEqual(riletto.Words(), d.Words()) || !reflect.DeepEqual(riletto.Schemas(), d.Schemas()) {
+		t.Errorf("dizionario riletto %v %v, atteso %v %v", riletto.Words(), riletto.Schemas(), d.Words(), d.Schemas())
+	}
+
+	// Lo stesso dizionario, comunque costruito, dà sempre gli stessi byte
+	var primo, secondo bytes.Buffer
+	d.WriteSnapshot(&primo)
+	nuovoDizionario(t, "aB", "ABCa", "aa", "abca", "abba").WriteSnapshot(&secondo)
+	if !bytes.Equal(primo.Bytes(), secondo.Bytes()) {
+		t.Errorf("snapshot diversi dello stesso dizionario:\n%q\n%q", primo.Bytes(), secondo.Bytes())
+	}
+}
+
+func TestSnapshotErrato(t *testing.T) {
+	var buf bytes.Buffer
+	if err := nuovoDizionario(t, "abba", "ABCa").WriteSnapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+	valido := buf.Bytes()
+
+	// Restituisce una copia di valido modificata da f
+	modifica := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte(nil), valido...))
+	}
+	type caso struct {
+		nome   string
+		dati   []byte
+		atteso error
+	}
+	casi := []caso{
+		{"vuoto", nil, ErrSnapshotFormat},
+		{"magic", modifica(func(b []byte) []byte { b[0] = 'X'; return b }), ErrSnapshotFormat},
+		{"versione", modifica(func(b []byte) []byte { b[4] = 9; return b }), ErrSnapshotVersion},
+		{"byte alterato", modifica(func(b []byte) []byte { b[10] ^= 1; return b }), ErrSnapshotCorrupt},
+		{"troncato", modifica(func(b []byte) []byte { return b[:len(b)-3] }), ErrSnapshotCorrupt},
+	}
+	// Snapshot con checksum corretto ma con voci non valide nella sua modalità
+	for _, voci := range []struct {
+		nome           string
+		parole, schemi []string
+	}{
+		{"parola con maiuscole", []string{"aB"}, nil},
+		{"schema senza variabili", nil, []string{"ab"}},
+		{"parola non ASCII", []string{"è"}, nil},
+		{"parola con cifre", []string{"a1"}, nil},
+	} {
+		d := New()
+		for _, w := range voci.parole {
+			d.parole[w] = struct{}{}
+		}
+		for _, w := range voci.schemi {
+			d.schemi[w] = struct{}{}
+		}
+		var buf bytes.Buffer
+		d.WriteSnapshot(&buf)
+		casi = append(casi, caso{voci.nome, buf.Bytes(), ErrSnapshotCorrupt})
+	}
+
+	for _, c := range casi {
+		t.Run(c.nome, func(t *testing.T) {
+			d := nuovoDizionario(t, "zzz")
+			if err := d.ReadSnapshot(bytes.NewReader(c.dati)); !errors.Is(err, c.atteso) {
+				t.Errorf("ReadSnapshot = %v, atteso %v", err, c.atteso)
+			}
+			// In caso di errore il dizionario resta invariato
+			if !reflect.DeepEqual(d.Words(), []string{"zzz"}) {
+				t.Errorf("dizionario modificato: %v", d.Words())
+			}
+		})
+	}
+}
+
+// Confronta il caricamento di uno snapshot con quello dello stesso dizionario in formato testo
+func BenchmarkCaricamento(b *testing.B) {
+	r := rand.New(rand.NewSource(5))
+	d := New()
+	for len(d.parole) < 50000 {
+		parola := make([]byte, 4+r.Intn(6))
+		for i := range parola {
+			parola[i] = byte('a' + r.Intn(26))
+		}
+		d.Insert(string(parola))
+	}
+	dir := b.TempDir()
+	snapshot, testo := filepath.Join(dir, "snapshot"), filepath.Join(dir, "testo")
+	if err := d.SaveSnapshot(snapshot); err != nil {
+		b.Fatal(err)
+	}
+	if err := d.Save(testo); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("snapshot", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := New().LoadSnapshot(snapshot); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("testo", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := New().Load(testo); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
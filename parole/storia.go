@@ -0,0 +1,79 @@
+package parole
+
+// Storia delle modifiche di un dizionario per annullarle e ripristinarle.
+// Ogni passo della storia è un gruppo di operazioni effettive (come restituite da Apply)
+// che vengono annullate o ripristinate insieme
+type History struct {
+	profondita int
+	annulla    [][]Op
+	ripeti     [][]Op
+}
+
+// Crea una storia che ricorda al più profondita passi; con profondita <= 0 non ricorda nulla
+func NewHistory(profondita int) *History {
+	return &History{profondita: profondita}
+}
+
+// Restituisce l'operazione che annulla l'operazione effettiva op
+func (op Op) Inverse() Op {
+	switch op.Kind {
+	case OpInsert:
+		return Op{Kind: OpDelete, Entries: op.Entries}
+	default: // OpDelete e OpReset: reinserisco le voci eliminate
+		return Op{Kind: OpInsert, Entries: op.Entries}
+	}
+}
+
+// Registra come nuovo passo le operazioni effettive ops, scartando i passi annullati.
+// I gruppi che non modificano il dizionario non vengono registrati
+func (h *History) Record(ops ...Op) {
+	var passo []Op
+	for _, op := range ops {
+		if !op.Empty() {
+			passo = append(passo, op)
+		}
+	}
+	if len(passo) == 0 || h.profondita <= 0 {
+		return
+	}
+	h.ripeti = nil
+	h.annulla = append(h.annulla, passo)
+	if len(h.annulla) > h.profondita {
+		h.annulla = h.annulla[len(h.annulla)-h.profondita:]
+	}
+}
+
+// Annulla sul dizionario d l'ultimo passo registrato.
+// Restituisce le operazioni effettivamente applicate e false se non c'è nulla da annullare
+func (h *History) Undo(d *Dictionary) ([]Op, bool) {
+	if len(h.annulla) == 0 {
+		return nil, false
+	}
+	passo := h.annulla[len(h.annulla)-1]
+	h.annulla = h.annulla[:len(h.annulla)-1]
+
+	// Le inverse vanno applicate in ordine opposto
+	applicate := make([]Op, 0, len(passo))
+	for i := len(passo) - 1; i >= 0; i-- {
+		applicate = append(applicate, d.Apply(passo[i].Inverse()))
+	}
+	h.ripeti = append(h.ripeti, passo)
+	return applicate, true
+}
+
+// Ripristina sul dizionario d l'ultimo passo annullato.
+// Restituisce le operazioni effettivamente applicate e false se non c'è nulla da ripristinare
+func (h *History) Redo(d *Dictionary) ([]Op, bool) {
+	if len(h.ripeti) == 0 {
+		return nil, false
+	}
+	passo := h.ripeti[len(h.ripeti)-1]
+	h.ripeti = h.ripeti[:len(h.ripeti)-1]
+
+	applicate := make([]Op, 0, len(passo))
+	for _, op := range passo {
+		applicate = append(applicate, d.Apply(op))
+	}
+	h.annulla = append(h.annulla, passo)
+	return applicate, true
+}
@@ -0,0 +1,64 @@
+package parole
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStoria(t *testing.T) {
+	d := New()
+	h := NewHistory(2)
+	applica := func(ops ...Op) {
+		var effettive []Op
+		for _, op := range ops {
+			effettive = append(effettive, d.Apply(op))
+		}
+		h.Record(effettive...)
+	}
+	controlla := func(atteso ...string) {
+		t.Helper()
+		if got := d.Entries(); !reflect.DeepEqual(got, atteso) && !(len(got) == 0 && len(atteso) == 0) {
+			t.Errorf("voci = %v, attese %v", got, atteso)
+		}
+	}
+
+	applica(Op{Kind: OpInsert, Entries: []string{"aa", "bb"}})
+	applica(Op{Kind: OpInsert, Entries: []string{"aa", "cc", "AB"}})
+	applica(Op{Kind: OpReset})
+	controlla()
+
+	// Annullare lo svuotamento reinserisce tutte le voci
+	h.Undo(d)
+	controlla("aa", "bb", "cc", "AB")
+	// Il secondo inserimento annulla solo le voci effettivamente aggiunte
+	h.Undo(d)
+	controlla("aa", "bb")
+	// La storia ricorda solo due passi
+	if _, ok := h.Undo(d); ok {
+		t.Errorf("Undo oltre la profondità della storia")
+	}
+
+	h.Redo(d)
+	controlla("aa", "bb", "cc", "AB")
+	h.Redo(d)
+	controlla()
+	if _, ok := h.Redo(d); ok {
+		t.Errorf("Redo senza passi annullati")
+	}
+
+	// Una nuova modifica scarta i passi annullati
+	h.Undo(d)
+	applica(Op{Kind: OpDelete, Entries: []string{"bb"}})
+	if _, ok := h.Redo(d); ok {
+		t.Errorf("Redo dopo una nuova modifica")
+	}
+	controlla("aa", "cc", "AB")
+
+	// Svuotare un dizionario già vuoto non crea un passo da annullare
+	vuoto := New()
+	h = NewHistory(2)
+	h.Record(vuoto.Apply(Op{Kind: OpReset}))
+	if _, ok := h.Undo(vuoto); ok {
+		t.Errorf("Undo di uno svuotamento senza effetto")
+	}
+}
@@ -0,0 +1,119 @@
+package parole
+
+import "sort"
+
+// Restituisce true se lo schema s è più generale dello schema t nella modalità del
+// dizionario d: ogni parola compatibile con t è compatibile anche con s.
+// Le parole sono trattate come schemi senza variabili
+func (d *Dictionary) Subsumes(s, t string) bool {
+	return sussume(d.simboli(s), d.simboli(t), d.variabile)
+}
+
+// Restituisce true se s è più generale di t: le lunghezze coincidono, i simboli fissi
+// di s si ritrovano in t nelle stesse posizioni e ogni variabile di s corrisponde
+// sempre allo stesso simbolo di t, fisso o variabile
+func sussume(s, t []rune, variabile func(rune) bool) bool {
+	if len(s) != len(t) {
+		return false
+	}
+	mappa := make(map[rune]rune)
+	for i, c := range s {
+		if !variabile(c) {
+			if t[i] != c {
+				return false
+			}
+			continue
+		}
+		if val, esiste := mappa[c]; esiste && val != t[i] {
+			return false
+		}
+		mappa[c] = t[i]
+	}
+	return true
+}
+
+// Restituisce true se esiste una parola compatibile sia con lo schema s sia con lo
+// schema t nella modalità del dizionario d
+func (d *Dictionary) Overlaps(s, t string) bool {
+	return sovrapposti(d.simboli(s), d.simboli(t), d.variabile)
+}
+
+// Simbolo di uno dei due schemi confrontati da sovrapposti: le variabili dei due
+// schemi sono distinte anche se uguali, i simboli fissi sono comuni
+type nodoSimbolo struct {
+	schema  int8 // 0 per i simboli fissi, 1 o 2 per le variabili del primo o del secondo schema
+	simbolo rune
+}
+
+// Restituisce true se s e t hanno una parola compatibile comune: unisce i simboli
+// delle due posizioni corrispondenti, che nella parola comune devono coincidere,
+// e verifica che nessuna classe contenga due simboli fissi diversi
+func sovrapposti(s, t []rune, variabile func(rune) bool) bool {
+	if len(s) != len(t) {
+		return false
+	}
+	padre := make(map[nodoSimbolo]nodoSimbolo)
+	var radice func(n nodoSimbolo) nodoSimbolo
+	radice = func(n nodoSimbolo) nodoSimbolo {
+		p, ok := padre[n]
+		if !ok || p == n {
+			return n
+		}
+		r := radice(p)
+		padre[n] = r
+		return r
+	}
+	nodo := func(schema int8, c rune) nodoSimbolo {
+		if !variabile(c) {
+			schema = 0
+		}
+		return nodoSimbolo{schema, c}
+	}
+
+	for i := range s {
+		a, b := radice(nodo(1, s[i])), radice(nodo(2, t[i]))
+		if a == b {
+			continue
+		}
+		// Ogni classe ha come radice il suo simbolo fisso, se ne contiene uno
+		if a.schema == 0 && b.schema == 0 {
+			return false
+		}
+		if a.schema == 0 {
+			a, b = b, a
+		}
+		padre[a] = b
+	}
+	return true
+}
+
+// Restituisce, in ordine alfabetico, il più piccolo insieme di schemi del dizionario d
+// con le stesse parole compatibili di tutti gli schemi: sono esclusi gli schemi di cui
+// un altro schema è più generale. Tra schemi equivalenti è conservato il primo in ordine alfabetico
+func (d *Dictionary) MinimalSchemas() []string {
+	// Schemi per lunghezza in simboli, in ordine alfabetico
+	perLunghezza := make(map[int][]string)
+	for _, s := range d.Schemas() {
+		n := len(d.simboli(s))
+		perLunghezza[n] = append(perLunghezza[n], s)
+	}
+
+	var minimi []string
+	for _, schemi := range perLunghezza {
+		for i, t := range schemi {
+			ridondante := false
+			for j, s := range schemi {
+				// t è ridondante se s è più generale e non equivalente, o equivalente e precedente
+				if j != i && d.Subsumes(s, t) && (j < i || !d.Subsumes(t, s)) {
+					ridondante = true
+					break
+				}
+			}
+			if !ridondante {
+				minimi = append(minimi, t)
+			}
+		}
+	}
+	sort.Strings(minimi)
+	return minimi
+}
@@ -0,0 +1,91 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// Restituisce tutte le parole di n simboli sull'alfabeto alfabeto
+func tutteLeParole(alfabeto []rune, n int) []string {
+	parole := []string{""}
+	for i := 0; i < n; i++ {
+		var prossime []string
+		for _, w := range parole {
+			for _, c := range alfabeto {
+				prossime = append(prossime, w+string(c))
+			}
+		}
+		parole = prossime
+	}
+	return parole
+}
+
+func TestSussunzione(t *testing.T) {
+	d := New()
+	casi := []struct {
+		s, t                 string
+		sussume, sovrapposti bool
+	}{
+		{"ABBA", "aBBa", true, true},
+		{"aBBa", "ABBA", false, true},
+		{"ABBA", "CDDC", true, true},
+		{"ABCD", "ABBA", true, true},
+		{"ABBA", "ABCD", false, true},
+		{"ABBA", "abca", false, false},
+		{"ABBA", "abba", true, true},
+		{"AbA", "cBd", false, false},
+		{"Ab", "aC", false, true},
+		{"Ab", "Ba", false, false},
+		{"AB", "ABC", false, false},
+		{"AAB", "CDD", false, true},
+		{"AAb", "CDD", false, true},
+		{"AAb", "cDD", false, false},
+	}
+	for _, c := range casi {
+		if got := d.Subsumes(c.s, c.t); got != c.sussume {
+			t.Errorf("Subsumes(%s, %s) = %v", c.s, c.t, got)
+		}
+		if got := d.Overlaps(c.s, c.t); got != c.sovrapposti {
+			t.Errorf("Overlaps(%s, %s) = %v", c.s, c.t, got)
+		}
+	}
+}
+
+func TestSussunzioneCasuale(t *testing.T) {
+	// Confronta le relazioni con gli insiemi delle parole compatibili, su un alfabeto
+	// con almeno tante lettere quanti sono i simboli fissi e le variabili degli schemi
+	r := rand.New(rand.NewSource(19))
+	alfabeto := []rune("abcde")
+	d := New()
+	schemi := paroleCasuali(r, 400, []rune("abXYZ"))
+	for i := 0; i+1 < len(schemi); i += 2 {
+		s, u := schemi[i], schemi[i+1]
+		if len(s) != len(u) {
+			continue
+		}
+		sussume, comune := true, false
+		for _, w := range tutteLeParole(alfabeto, len(s)) {
+			cs, cu := d.Compatible(w, s), d.Compatible(w, u)
+			if cu && !cs {
+				sussume = false
+			}
+			if cs && cu {
+				comune = true
+			}
+		}
+		if got := d.Subsumes(s, u); got != sussume {
+			t.Errorf("Subsumes(%s, %s) = %v, atteso %v", s, u, got, sussume)
+		}
+		if got := d.Overlaps(s, u); got != comune {
+			t.Errorf("Overlaps(%s, %s) = %v, atteso %v", s, u, got, comune)
+		}
+	}
+}
+
+func TestSchemiMinimi(t *testing.T) {
+	d := nuovoDizionario(t, "ABBA", "aBBa", "CDDC", "ABCD", "aBc", "Abc", "XY", "ab")
+	if got, want := d.MinimalSchemas(), []string{"ABCD", "Abc", "XY", "aBc"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MinimalSchemas() = %v, atteso %v", got, want)
+	}
+}
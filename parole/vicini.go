@@ -0,0 +1,112 @@
+package parole
+
+// Indice dei vicini a distanza di editing 1, basato sulle firme di cancellazione:
+// per ogni parola w e ogni posizione i, la stringa ottenuta cancellando il simbolo
+// in posizione i è la chiave di un secchiello che contiene l'occorrenza (w, i).
+//
+// Per una parola w di lunghezza n:
+//   - le parole di lunghezza n che differiscono da w solo in posizione i sono quelle
+//     del secchiello di w senza il simbolo i, registrate con la stessa posizione i;
+//   - le parole di lunghezza n+1 ottenute inserendo un simbolo in w sono quelle del
+//     secchiello con chiave w;
+//   - le parole di lunghezza n-1 ottenute cancellando un simbolo di w sono le sue
+//     firme di cancellazione presenti nel dizionario.
+type indiceVicini struct {
+	secchielli map[string][]occorrenza
+}
+
+// Parola registrata in un secchiello, con la posizione del simbolo cancellato e la lunghezza in simboli
+type occorrenza struct {
+	parola    string
+	posizione int32
+	lunghezza int32
+}
+
+// Crea un indice vuoto
+func nuovoIndiceVicini() *indiceVicini {
+	return &indiceVicini{secchielli: make(map[string][]occorrenza)}
+}
+
+// Restituisce la stringa ottenuta cancellando da s il simbolo in posizione i
+func cancella(s []rune, i int) string {
+	return string(s[:i]) + string(s[i+1:])
+}
+
+// Registra nell'indice la parola w, di simboli s
+func (x *indiceVicini) aggiungi(w string, s []rune) {
+	for i := range s {
+		chiave := cancella(s, i)
+		x.secchielli[chiave] = append(x.secchielli[chiave], occorrenza{w, int32(i), int32(len(s))})
+	}
+}
+
+// Rimuove dall'indice la parola w, di simboli s
+func (x *indiceVicini) rimuovi(w string, s []rune) {
+	for i := range s {
+		chiave := cancella(s, i)
+		secchiello := x.secchielli[chiave]
+		for j, o := range secchiello {
+			if o.parola == w && o.posizione == int32(i) {
+				secchiello[j] = secchiello[len(secchiello)-1]
+				secchiello = secchiello[:len(secchiello)-1]
+				break
+			}
+		}
+		if len(secchiello) == 0 {
+			delete(x.secchielli, chiave)
+		} else {
+			x.secchielli[chiave] = secchiello
+		}
+	}
+}
+
+// Restituisce le parole del dizionario d a distanza di editing 1 dalla parola w
+func (d *Dictionary) vicini(w string) []string {
+	return append(d.viciniSostituzione(w), d.viciniInserimentoCancellazione(w)...)
+}
+
+// Restituisce le parole del dizionario d che si ottengono da w sostituendo un simbolo
+func (d *Dictionary) viciniSostituzione(w string) []string {
+	s := d.simboli(w)
+	var vicini []string
+	for i := range s {
+		for _, o := range d.indice.secchielli[cancella(s, i)] {
+			if o.lunghezza == int32(len(s)) && o.posizione == int32(i) && o.parola != w {
+				vicini = append(vicini, o.parola)
+			}
+		}
+	}
+	return vicini
+}
+
+// Restituisce le parole del dizionario d che si ottengono da w cancellando o inserendo un simbolo
+func (d *Dictionary) viciniInserimentoCancellazione(w string) []string {
+	s := d.simboli(w)
+	var vicini []string
+
+	// Cancellazioni: cancellando simboli uguali e adiacenti si ottiene la stessa parola, la considero una volta
+	for i := range s {
+		if i > 0 && s[i] == s[i-1] {
+			continue
+		}
+		if chiave := cancella(s, i); d.HasWord(chiave) {
+			vicini = append(vicini, chiave)
+		}
+	}
+
+	// Inserimenti: una parola può comparire più volte nel secchiello se contiene simboli ripetuti
+	var visto map[string]bool
+	for _, o := range d.indice.secchielli[w] {
+		if o.lunghezza != int32(len(s))+1 {
+			continue
+		}
+		if visto == nil {
+			visto = make(map[string]bool)
+		}
+		if !visto[o.parola] {
+			visto[o.parola] = true
+			vicini = append(vicini, o.parola)
+		}
+	}
+	return vicini
+}
@@ -0,0 +1,61 @@
+package parole
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// Genera n parole casuali di lunghezza tra 1 e 4 sull'alfabeto alfabeto
+func paroleCasuali(r *rand.Rand, n int, alfabeto []rune) []string {
+	parole := make([]string, n)
+	for i := range parole {
+		s := make([]rune, 1+r.Intn(4))
+		for j := range s {
+			s[j] = alfabeto[r.Intn(len(alfabeto))]
+		}
+		parole[i] = string(s)
+	}
+	return parole
+}
+
+// Restituisce true se la distanza di editing tra le stringhe x e y è 1, false altrimenti
+func (d *Dictionary) isSimile(x, y string) bool {
+	return d.Distance(x, y) == 1
+}
+
+// Confronta i vicini dell'indice con quelli trovati calcolando la distanza con ogni parola
+func controllaVicini(t *testing.T, d *Dictionary) {
+	t.Helper()
+	for w := range d.parole {
+		got := d.vicini(w)
+		var want []string
+		for v := range d.parole {
+			if d.isSimile(w, v) {
+				want = append(want, v)
+			}
+		}
+		sort.Strings(got)
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) && len(got)+len(want) > 0 {
+			t.Fatalf("vicini(%s) = %v, attesi %v", w, got, want)
+		}
+	}
+}
+
+func TestIndiceVicini(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, d := range []*Dictionary{New(), NewUnicode()} {
+		alfabeto := []rune("abc")
+		if d.Unicode() {
+			alfabeto = []rune("aèò")
+		}
+		d.Apply(Op{Kind: OpInsert, Entries: paroleCasuali(r, 60, alfabeto)})
+		controllaVicini(t, d)
+
+		// L'indice resta coerente dopo le eliminazioni
+		d.Apply(Op{Kind: OpDelete, Entries: paroleCasuali(r, 60, alfabeto)})
+		controllaVicini(t, d)
+	}
+}
@@ -0,0 +1,171 @@
+package repl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"solution/parole"
+)
+
+// Strategie di ricerca selezionabili con l'opzione algo=
+var strategie = map[string]parole.Strategy{
+	"bid":   parole.StrategyBidirectional,
+	"bfs":   parole.StrategyBFS,
+	"astar": parole.StrategyAStar,
+}
+
+// Relazioni di passo selezionabili con l'opzione passo=; distK seleziona StepWithin(K)
+var passi = map[string]parole.Step{
+	"lev":   parole.StepEdit,
+	"sost":  parole.StepSubstitution,
+	"indel": parole.StepInsertDelete,
+	"trasp": parole.StepTransposition,
+	"anagr": parole.StepAnagram,
+}
+
+// Restituisce la relazione di passo di nome nome
+func passo(nome string) (parole.Step, bool) {
+	if k, err := strconv.Atoi(strings.TrimPrefix(nome, "dist")); err == nil && strings.HasPrefix(nome, "dist") && k > 0 {
+		return parole.StepWithin(k), true
+	}
+	s, ok := passi[nome]
+	return s, ok
+}
+
+// Esegue il comando "c x y [opzioni]": stampa una catena di lunghezza minima tra x e y.
+// Opzioni:
+//
+//	algo=...      strategia di ricerca: bid (bidirezionale, predefinita), bfs (in ampiezza),
+//	              astar (A* guidata dalla distanza di editing dalla destinazione)
+//	stat          stampa dopo la catena il numero di parole espanse
+//	cfr           come stat, stampando anche le parole espanse dalla visita in ampiezza
+//	passo=...     relazione tra parole consecutive: lev (distanza 1, predefinita), sost
+//	              (sostituzione), indel (inserimento o cancellazione), trasp (anche scambio
+//	              di simboli adiacenti), anagr (anagramma), distK (distanza al più K)
+//	-w            la catena non può contenere la parola w
+//	+w            la catena deve passare per la parola w, nell'ordine delle opzioni
+func (e *Engine) catena(campi []string) Status {
+	var opzioni parole.ChainOptions
+	statistiche, confronto := false, false
+	for _, o := range campi[3:] {
+		switch {
+		case o == "stat":
+			statistiche = true
+		case o == "cfr":
+			confronto = true
+		case len(o) > 1 && o[0] == '-':
+			opzioni.Avoid = append(opzioni.Avoid, o[1:])
+		case len(o) > 1 && o[0] == '+':
+			opzioni.Via = append(opzioni.Via, o[1:])
+		case strings.HasPrefix(o, "algo="):
+			s, ok := strategie[strings.TrimPrefix(o, "algo=")]
+			if !ok {
+				return e.formatoErrato("c")
+			}
+			opzioni.Strategy = s
+		case strings.HasPrefix(o, "passo="):
+			p, ok := passo(strings.TrimPrefix(o, "passo="))
+			if !ok {
+				return e.formatoErrato("c")
+			}
+			opzioni.Step = p
+		default:
+			return e.formatoErrato("c")
+		}
+	}
+
+	risultato, err := e.d.ChainWith(campi[1], campi[2], opzioni)
+	parole.WriteChain(e.out, risultato.Chain, err)
+	if statistiche || confronto {
+		fmt.Fprintln(e.out, "espansi:", risultato.Expanded)
+	}
+	if confronto {
+		opzioni.Strategy = parole.StrategyBFS
+		bfs, _ := e.d.ChainWith(campi[1], campi[2], opzioni)
+		fmt.Fprintln(e.out, "espansi bfs:", bfs.Expanded)
+	}
+	return StatusContinue
+}
+
+// Esegue i comandi "ct x y [max]", che stampa le catene di lunghezza minima tra x e y
+// in ordine lessicografico, "cn x y [max]", che ne stampa il numero, e "ck x y k", che
+// stampa le k catene senza parole ripetute più corte tra x e y. Con max si stampano
+// al più max catene, o si conta fino a max
+func (e *Engine) catene(campi []string) Status {
+	if len(campi) != 4 && (len(campi) != 3 || campi[0] == "ck") {
+		return e.formatoErrato(campi[0])
+	}
+	limite := 0
+	if len(campi) == 4 {
+		n, err := strconv.Atoi(campi[3])
+		if err != nil || n <= 0 {
+			return e.formatoErrato(campi[0])
+		}
+		limite = n
+	}
+
+	x, y := campi[1], campi[2]
+	var catene [][]string
+	var err error
+	switch campi[0] {
+	case "cn":
+		n, err := e.d.CountShortestChains(x, y, limite)
+		if err != nil {
+			fmt.Fprintln(e.out, "non esiste")
+		} else {
+			fmt.Fprintln(e.out, n)
+		}
+		return StatusContinue
+	case "ck":
+		catene, err = e.d.KShortestChains(x, y, limite)
+	default:
+		catene, err = e.d.AllShortestChains(x, y, limite)
+	}
+
+	if err != nil {
+		parole.WriteChain(e.out, nil, err)
+	}
+	for _, c := range catene {
+		parole.WriteChain(e.out, c, nil)
+	}
+	return StatusContinue
+}
+
+// Esegue il comando "cw x y [costi]": stampa una catena di costo minimo tra x e y e il suo
+// costo. I costi, predefiniti a 1, si indicano con sost=N (sostituzione), ins=N
+// (inserimento), canc=N (cancellazione) e l=N (costo aggiuntivo del simbolo l inserito,
+// cancellato o sostituito a un altro)
+func (e *Engine) catenaPesata(campi []string) Status {
+	if len(campi) < 3 {
+		return e.formatoErrato("cw")
+	}
+	costi := parole.DefaultCostModel
+	costi.Letter = make(map[rune]int)
+	for _, o := range campi[3:] {
+		nome, valore, ok := strings.Cut(o, "=")
+		n, err := strconv.Atoi(valore)
+		if !ok || err != nil || n < 0 {
+			return e.formatoErrato("cw")
+		}
+		switch simboli := []rune(nome); {
+		case nome == "sost":
+			costi.Substitution = n
+		case nome == "ins":
+			costi.Insertion = n
+		case nome == "canc":
+			costi.Deletion = n
+		case len(simboli) == 1:
+			costi.Letter[simboli[0]] = n
+		default:
+			return e.formatoErrato("cw")
+		}
+	}
+
+	catena, costo, err := e.d.WeightedChain(campi[1], campi[2], costi)
+	parole.WriteChain(e.out, catena, err)
+	if err == nil {
+		fmt.Fprintln(e.out, "costo:", costo)
+	}
+	return StatusContinue
+}
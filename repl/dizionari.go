@@ -0,0 +1,111 @@
+package repl
+
+import (
+	"fmt"
+	"sort"
+
+	"solution/parole"
+)
+
+// Aggiunge alla sessione il dizionario d con nome nome
+func (e *Engine) aggiungi(nome string, d *parole.Dictionary) {
+	e.dizionari[nome] = &voce{d: d, storia: parole.NewHistory(e.profondita)}
+}
+
+// Seleziona il dizionario nome, su cui operano i comandi successivi
+func (e *Engine) seleziona(nome string) {
+	v := e.dizionari[nome]
+	e.corrente = nome
+	e.d = v.d
+	e.storia = v.storia
+}
+
+// Esegue i comandi di gestione dei dizionari con nome:
+//
+//	dn nome     crea un nuovo dizionario vuoto, nella modalità di quello selezionato
+//	dl          stampa i nomi dei dizionari, segnando con * quello selezionato
+//	ds nome     seleziona il dizionario
+//	dc src dst  copia il dizionario src in un nuovo dizionario dst
+//	de nome     elimina il dizionario
+//	du a b dst  crea il dizionario dst unione di a e b
+//	di a b dst  crea il dizionario dst intersezione di a e b
+//	dd a b dst  crea il dizionario dst differenza tra a e b
+func (e *Engine) gestisci(campi []string) Status {
+	argomenti := map[string]int{"dn": 1, "dl": 0, "ds": 1, "dc": 2, "de": 1, "du": 3, "di": 3, "dd": 3}
+	if len(campi)-1 != argomenti[campi[0]] {
+		return e.formatoErrato(campi[0])
+	}
+
+	switch campi[0] {
+	case "dn": // NUOVO DIZIONARIO
+		if !e.nonEsiste(campi[1]) {
+			return StatusError
+		}
+		e.aggiungi(campi[1], e.d.NewEmpty())
+
+	case "dl": // LISTA DEI DIZIONARI
+		nomi := make([]string, 0, len(e.dizionari))
+		for nome := range e.dizionari {
+			nomi = append(nomi, nome)
+		}
+		sort.Strings(nomi)
+		for i, nome := range nomi {
+			if nome == e.corrente {
+				nomi[i] += " *"
+			}
+		}
+		parole.WriteSet(e.out, nomi)
+
+	case "ds": // SELEZIONA DIZIONARIO
+		if !e.esiste(campi[1]) {
+			return StatusError
+		}
+		e.seleziona(campi[1])
+
+	case "dc": // COPIA DIZIONARIO
+		if !e.esiste(campi[1]) || !e.nonEsiste(campi[2]) {
+			return StatusError
+		}
+		e.aggiungi(campi[2], e.dizionari[campi[1]].d.Clone())
+
+	case "de": // ELIMINA DIZIONARIO
+		if !e.esiste(campi[1]) {
+			return StatusError
+		}
+		if campi[1] == e.corrente || campi[1] == Principale {
+			fmt.Fprintln(e.out, "Impossibile eliminare il dizionario", campi[1])
+			return StatusError
+		}
+		delete(e.dizionari, campi[1])
+
+	case "du", "di", "dd": // OPERAZIONI INSIEMISTICHE
+		if !e.esiste(campi[1]) || !e.esiste(campi[2]) || !e.nonEsiste(campi[3]) {
+			return StatusError
+		}
+		operazioni := map[string]func(a, b *parole.Dictionary) *parole.Dictionary{
+			"du": parole.Union,
+			"di": parole.Intersection,
+			"dd": parole.Difference,
+		}
+		e.aggiungi(campi[3], operazioni[campi[0]](e.dizionari[campi[1]].d, e.dizionari[campi[2]].d))
+	}
+	return StatusContinue
+}
+
+// Restituisce true se il dizionario nome esiste, altrimenti lo segnala
+func (e *Engine) esiste(nome string) bool {
+	if _, ok := e.dizionari[nome]; !ok {
+		fmt.Fprintln(e.out, "Dizionario inesistente:", nome)
+		return false
+	}
+	return true
+}
+
+// Restituisce true se il dizionario nome non esiste, altrimenti lo segnala
+func (e *Engine) nonEsiste(nome string) bool {
+	if _, ok := e.dizionari[nome]; ok {
+		fmt.Fprintln(e.out, "Dizionario già esistente:", nome)
+		return false
+	}
+	return true
+}
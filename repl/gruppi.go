@@ -0,0 +1,54 @@
+package repl
+
+import (
+	"fmt"
+	"strconv"
+
+	"solution/parole"
+)
+
+// Esegue i comandi di analisi del grafo delle parole simili:
+//
+//	gg w   stampa il grado di w, il numero delle parole simili
+//	gh n   stampa le n parole di grado massimo, ciascuna con il suo grado
+//	gi     stampa le parole senza parole simili
+//	ge w   stampa l'eccentricità di w, la massima lunghezza di una catena minima da w
+//	gd     stampa per ogni gruppo la dimensione, il diametro e una catena lunga quanto il diametro
+func (e *Engine) analisi(campi []string) Status {
+	argomenti := map[string]int{"gg": 2, "gh": 2, "gi": 1, "ge": 2, "gd": 1}
+	if len(campi) != argomenti[campi[0]] {
+		return e.formatoErrato(campi[0])
+	}
+
+	switch campi[0] {
+	case "gg", "ge":
+		misura := e.d.Degree
+		if campi[0] == "ge" {
+			misura = e.d.Eccentricity
+		}
+		if n, ok := misura(campi[1]); ok {
+			fmt.Fprintln(e.out, n)
+		} else {
+			fmt.Fprintln(e.out, "non esiste")
+		}
+
+	case "gh":
+		n, err := strconv.Atoi(campi[1])
+		if err != nil || n <= 0 {
+			return e.formatoErrato("gh")
+		}
+		for _, h := range e.d.Hubs(n) {
+			fmt.Fprintf(e.out, "%s: %d\n", h.Word, h.Degree)
+		}
+
+	case "gi":
+		parole.WriteSet(e.out, e.d.Isolated())
+
+	case "gd":
+		for _, g := range e.d.Diameters() {
+			fmt.Fprintf(e.out, "dimensione: %d, diametro: %d\n", g.Size, g.Diameter)
+			parole.WriteChain(e.out, g.Chain, nil)
+		}
+	}
+	return StatusContinue
+}
@@ -0,0 +1,338 @@
+// Package repl implementa l'interprete dei comandi testuali del dizionario:
+// legge le linee da un io.Reader, esegue le operazioni sul dizionario e
+// scrive i risultati su un io.Writer.
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"solution/parole"
+)
+
+// Esito dell'esecuzione di un comando
+type Status int
+
+const (
+	StatusContinue  Status = iota // comando eseguito, la sessione prosegue
+	StatusTerminate               // comando di terminazione "t"
+	StatusError                   // comando non riconosciuto o in formato errato
+)
+
+const formatoErrato = "Formato errato per il comando"
+
+// Interprete dei comandi su un dizionario
+type Engine struct {
+	in  io.Reader
+	out io.Writer
+
+	dizionari  map[string]*voce // dizionari della sessione per nome
+	corrente   string           // nome del dizionario selezionato
+	d          *parole.Dictionary
+	storia     *parole.History // modifiche del dizionario selezionato da annullare con "u" e ripristinare con "y"
+	profondita int             // profondità della storia di ciascun dizionario
+
+	journal  *parole.Journal // se non nil, registra le modifiche del dizionario principale
+	snapshot string          // file dello snapshot usato dalla compattazione del journal
+}
+
+// Dizionario della sessione con la propria storia delle modifiche
+type voce struct {
+	d      *parole.Dictionary
+	storia *parole.History
+}
+
+// Nome del dizionario con cui inizia la sessione
+const Principale = "principale"
+
+// Numero di modifiche annullabili predefinito
+const ProfonditaStoria = 100
+
+// Numero di gruppi per modello stampati dal comando pm senza argomento
+const gruppiModello = 10
+
+// Crea un interprete che opera sul dizionario d, legge i comandi da in e scrive su out.
+// d è il dizionario Principale, selezionato all'inizio della sessione.
+// in può essere nil se l'interprete viene usato solo tramite Execute
+func New(d *parole.Dictionary, in io.Reader, out io.Writer) *Engine {
+	e := &Engine{in: in, out: out, dizionari: make(map[string]*voce), profondita: ProfonditaStoria}
+	e.aggiungi(Principale, d)
+	e.seleziona(Principale)
+	return e
+}
+
+// Imposta il numero massimo di modifiche annullabili, scartando la storia corrente di tutti i dizionari
+func (e *Engine) SetHistoryDepth(profondita int) {
+	e.profondita = profondita
+	for _, v := range e.dizionari {
+		v.storia = parole.NewHistory(profondita)
+	}
+	e.storia = e.dizionari[e.corrente].storia
+}
+
+// Registra nel journal j ogni modifica del dizionario Principale; la compattazione salva lo snapshot nel file snapshot
+func (e *Engine) SetJournal(j *parole.Journal, snapshot string) {
+	e.journal = j
+	e.snapshot = snapshot
+}
+
+// Legge ed esegue i comandi fino alla fine dell'input o al comando di terminazione.
+// Restituisce StatusTerminate se la sessione è stata chiusa con "t", StatusContinue altrimenti
+func (e *Engine) Run() (Status, error) {
+	scanner := bufio.NewScanner(e.in)
+	for scanner.Scan() {
+		linea := scanner.Text()
+		if linea == "" {
+			continue
+		}
+		// esegue il comando sulla linea letta
+		if e.Execute(linea) == StatusTerminate {
+			return StatusTerminate, nil
+		}
+	}
+	return StatusContinue, scanner.Err()
+}
+
+// Attraverso la stringa s, esegue le varie operazioni sul dizionario
+func (e *Engine) Execute(s string) Status {
+	campi := strings.Fields(s)
+	if len(campi) == 0 {
+		return StatusContinue
+	}
+
+	switch campi[0] {
+	case "c": // INIZIALIZZA "c", CARICA FILE "c nomeFile", CATENA "c x y [opzioni]"
+		if len(campi) == 1 { // CREA
+			e.applica(parole.Op{Kind: parole.OpReset})
+
+		} else if len(campi) == 2 { // CARICA
+			e.carica(campi[1])
+
+		} else { // CATENA
+			return e.catena(campi)
+		}
+
+	case "t": // TERMINA ESECUZIONE
+		fmt.Fprintln(e.out, "Esecuzione terminata")
+		return StatusTerminate
+
+	case "p": // STAMPA PAROLE
+		if len(campi) != 1 {
+			return e.formatoErrato("p")
+		}
+		parole.WriteSet(e.out, e.d.Words())
+
+	case "s": // STAMPA SCHEMI
+		if len(campi) != 1 {
+			return e.formatoErrato("s")
+		}
+		parole.WriteSet(e.out, e.d.Schemas())
+
+	case "i": // INSERISCI PAROLA/SCHEMA
+		if len(campi) != 2 { // Controllo formato comando
+			return e.formatoErrato("i")
+		}
+		if !e.d.IsValid(campi[1]) { // controllo formato parola/schema
+			fmt.Fprintln(e.out, "Parola/schema non valida")
+			return StatusError
+		}
+		e.applica(parole.Op{Kind: parole.OpInsert, Entries: campi[1:]})
+
+	case "e": // ELIMINA PAROLA/SCHEMA
+		if len(campi) != 2 { // Controllo formato comando
+			return e.formatoErrato("e")
+		}
+		e.applica(parole.Op{Kind: parole.OpDelete, Entries: campi[1:]})
+
+	case "r": // STAMPA LO SCHEMA E LE PAROLE COMPATIBILI, "r S inj" IN MODALITÀ INIETTIVA
+		if len(campi) != 2 && (len(campi) != 3 || campi[2] != "inj") { // Controllo formato comando
+			return e.formatoErrato("r")
+		}
+		modo := parole.MatchDefault
+		if len(campi) == 3 {
+			modo = parole.MatchInjective
+		}
+		schema := campi[1]
+		parole.WriteMatch(e.out, schema, e.d.MatchWith(schema, modo))
+
+	case "rs": // STAMPA LA PAROLA E GLI SCHEMI COMPATIBILI
+		if len(campi) != 2 {
+			return e.formatoErrato("rs")
+		}
+		if !e.d.IsValid(campi[1]) || e.d.IsSchema(campi[1]) {
+			fmt.Fprintln(e.out, "Parola/schema non valida")
+			return StatusError
+		}
+		parole.WriteMatch(e.out, campi[1], e.d.MatchingSchemas(campi[1]))
+
+	case "pm": // STAMPA I GRUPPI PIÙ GRANDI DI PAROLE CON LO STESSO MODELLO
+		if len(campi) > 2 {
+			return e.formatoErrato("pm")
+		}
+		n := gruppiModello
+		if len(campi) == 2 {
+			var err error
+			if n, err = strconv.Atoi(campi[1]); err != nil || n <= 0 {
+				return e.formatoErrato("pm")
+			}
+		}
+		for _, g := range e.d.PatternGroups(n) {
+			fmt.Fprintf(e.out, "%s: %d\n", g.Pattern, len(g.Words))
+		}
+
+	case "sg", "so", "sm": // RELAZIONI TRA SCHEMI
+		return e.relazioniSchemi(campi)
+
+	case "w": // SALVA SU FILE
+		if len(campi) != 2 {
+			return e.formatoErrato("w")
+		}
+		if err := e.d.Save(campi[1]); err != nil {
+			fmt.Fprintln(e.out, "Errore di salvataggio:", err)
+			return StatusError
+		}
+
+	case "ws": // SALVA SNAPSHOT BINARIO
+		if len(campi) != 2 {
+			return e.formatoErrato("ws")
+		}
+		if err := e.d.SaveSnapshot(campi[1]); err != nil {
+			fmt.Fprintln(e.out, "Errore di salvataggio:", err)
+			return StatusError
+		}
+
+	case "cs": // CARICA SNAPSHOT BINARIO
+		if len(campi) != 2 {
+			return e.formatoErrato("cs")
+		}
+		effettive, err := e.d.ReplaceWithSnapshot(campi[1])
+		if err != nil {
+			fmt.Fprintln(e.out, "Errore di caricamento:", err)
+			return StatusError
+		}
+		e.storia.Record(effettive...)
+		e.registra(effettive)
+
+	case "j": // COMPATTA IL JOURNAL NELLO SNAPSHOT
+		if len(campi) != 1 {
+			return e.formatoErrato("j")
+		}
+		if e.journal == nil {
+			fmt.Fprintln(e.out, "Journal non attivo")
+			return StatusError
+		}
+		if err := e.journal.Compact(e.dizionari[Principale].d, e.snapshot); err != nil {
+			fmt.Fprintln(e.out, "Errore di compattazione:", err)
+			return StatusError
+		}
+
+	case "u": // ANNULLA L'ULTIMA MODIFICA
+		if len(campi) != 1 {
+			return e.formatoErrato("u")
+		}
+		applicate, ok := e.storia.Undo(e.d)
+		if !ok {
+			fmt.Fprintln(e.out, "Nessuna modifica da annullare")
+			return StatusError
+		}
+		e.registra(applicate)
+
+	case "y": // RIPRISTINA L'ULTIMA MODIFICA ANNULLATA
+		if len(campi) != 1 {
+			return e.formatoErrato("y")
+		}
+		applicate, ok := e.storia.Redo(e.d)
+		if !ok {
+			fmt.Fprintln(e.out, "Nessuna modifica da ripristinare")
+			return StatusError
+		}
+		e.registra(applicate)
+
+	case "ct", "cn", "ck": // TUTTE LE CATENE MINIME, LE K CATENE PIÙ CORTE
+		return e.catene(campi)
+
+	case "cw": // CATENA DI COSTO MINIMO
+		return e.catenaPesata(campi)
+
+	case "g": // STAMPA IL GRUPPO DELLA PAROLA
+		if len(campi) != 2 {
+			return e.formatoErrato("g")
+		}
+		if gruppo := e.d.Group(campi[1]); gruppo != nil {
+			parole.WriteSet(e.out, gruppo)
+		} else {
+			fmt.Fprintln(e.out, "non esiste")
+		}
+
+	case "gn": // STAMPA NUMERO E DIMENSIONI DEI GRUPPI
+		if len(campi) != 1 {
+			return e.formatoErrato("gn")
+		}
+		gruppi := e.d.Groups()
+		dimensioni := make([]string, len(gruppi))
+		for i, g := range gruppi {
+			dimensioni[i] = strconv.Itoa(len(g))
+		}
+		fmt.Fprintln(e.out, "gruppi:", len(gruppi))
+		fmt.Fprintln(e.out, "dimensioni:", strings.Join(dimensioni, " "))
+
+	case "gg", "gh", "gi", "ge", "gd": // ANALISI DEL GRAFO DELLE PAROLE SIMILI
+		return e.analisi(campi)
+
+	case "dn", "dl", "ds", "dc", "de", "du", "di", "dd": // GESTIONE DEI DIZIONARI CON NOME
+		return e.gestisci(campi)
+
+	case "d": // STAMPA DISTANZA DI EDITING
+		if len(campi) != 3 {
+			return e.formatoErrato("d")
+		}
+		fmt.Fprintln(e.out, e.d.Distance(campi[1], campi[2]))
+
+	default:
+		fmt.Fprintln(e.out, "Comando non riconosciuto")
+		return StatusError
+	}
+	return StatusContinue
+}
+
+// Carica sul dizionario le parole/schemi del file file, segnalando quelli in formato errato
+func (e *Engine) carica(file string) {
+	// file non esistente -> non fare nulla
+	voci, scartate, _ := e.d.ReadFile(file)
+	for _, w := range scartate {
+		fmt.Fprintf(e.out, "formato errato per la parola/schema -> %s <-\n", w)
+	}
+	e.applica(parole.Op{Kind: parole.OpInsert, Entries: voci})
+}
+
+// Applica in sequenza le operazioni ops al dizionario come un unico passo annullabile
+func (e *Engine) applica(ops ...parole.Op) {
+	effettive := make([]parole.Op, 0, len(ops))
+	for _, op := range ops {
+		effettive = append(effettive, e.d.Apply(op))
+	}
+	e.storia.Record(effettive...)
+	e.registra(effettive)
+}
+
+// Registra nel journal, se attivo, le operazioni effettive ops sul dizionario Principale
+func (e *Engine) registra(ops []parole.Op) {
+	if e.journal == nil || e.corrente != Principale {
+		return
+	}
+	for _, op := range ops {
+		if err := e.journal.Append(op); err != nil {
+			fmt.Fprintln(e.out, "Errore di scrittura del journal:", err)
+			return
+		}
+	}
+}
+
+// Segnala il formato errato del comando cmd
+func (e *Engine) formatoErrato(cmd string) Status {
+	fmt.Fprintln(e.out, formatoErrato, cmd)
+	return StatusError
+}
@@ -0,0 +1,356 @@
+package repl
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"solution/parole"
+)
+
+// Esegue la sessione input su un nuovo dizionario e restituisce l'esito e l'output prodotto
+func sessione(t *testing.T, input string) (Status, string) {
+	t.Helper()
+	var out strings.Builder
+	stato, err := New(parole.New(), strings.NewReader(input), &out).Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	return stato, out.String()
+}
+
+func TestSessione(t *testing.T) {
+	casi := []struct {
+		nome   string
+		input  string
+		stato  Status
+		atteso string
+	}{
+		{"base",
+			"c\ni abba\ni ABCa\np\ns\nt\n",
+			StatusTerminate,
+			"[\nabba\n]\n[\nABCa\n]\nEsecuzione terminata\n"},
+		{"comandi dopo la terminazione ignorati",
+			"i aa\nt\np\n",
+			StatusTerminate,
+			"Esecuzione terminata\n"},
+		{"fine input senza terminazione",
+			"i aa\ni aba\ni bba\n\nc aa bba\nr aBa\n",
+			StatusContinue,
+			"(\naa\naba\nbba\n)\naBa:[\naba\n]\n"},
+		{"errori di formato",
+			"x\np p\ni a1\nd aa\n",
+			StatusContinue,
+			"Comando non riconosciuto\nFormato errato per il comando p\nParola/schema non valida\nFormato errato per il comando d\n"},
+	}
+	for _, c := range casi {
+		t.Run(c.nome, func(t *testing.T) {
+			stato, out := sessione(t, c.input)
+			if stato != c.stato {
+				t.Errorf("stato = %v, atteso %v", stato, c.stato)
+			}
+			if out != c.atteso {
+				t.Errorf("output:\n%s\natteso:\n%s", out, c.atteso)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	var out strings.Builder
+	e := New(parole.New(), nil, &out)
+
+	if s := e.Execute("i aa"); s != StatusContinue {
+		t.Errorf("Execute(i aa) = %v", s)
+	}
+	if s := e.Execute("zz"); s != StatusError {
+		t.Errorf("Execute(zz) = %v", s)
+	}
+	if s := e.Execute("t"); s != StatusTerminate {
+		t.Errorf("Execute(t) = %v", s)
+	}
+}
+
+func TestSalva(t *testing.T) {
+	nome := t.TempDir() + "/dizionario"
+	_, out := sessione(t, "i abba\ni ABCa\nw "+nome+"\nc\nc "+nome+"\np\ns\n")
+	if atteso := "[\nabba\n]\n[\nABCa\n]\n"; out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	nome := t.TempDir() + "/dizionario.snap"
+	_, out := sessione(t, "i abba\ni ABCa\nws "+nome+"\nc\ni zz\ncs "+nome+"\np\ns\ncs "+nome+".manca\n")
+	atteso := "[\nabba\n]\n[\nABCa\n]\n"
+	if !strings.HasPrefix(out, atteso) || !strings.Contains(out[len(atteso):], "Errore di caricamento:") {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestJournal(t *testing.T) {
+	dir := t.TempDir()
+	d := parole.New()
+	j, err := parole.OpenJournal(dir+"/journal", d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	e := New(d, strings.NewReader("i aa\ni bb\ne aa\nj\ni cc\n"), &out)
+	e.SetJournal(j, dir+"/snapshot")
+	if _, err := e.Run(); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+
+	ripristinato := parole.New()
+	ripristinato.LoadSnapshot(dir + "/snapshot")
+	if _, err := parole.OpenJournal(dir+"/journal", ripristinato); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(ripristinato.Words(), " "); got != "bb cc" || out.String() != "" {
+		t.Errorf("parole ripristinate %q, output %q", got, out.String())
+	}
+}
+
+func TestJournalSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	sessione(t, "i bb\ni cc\nws "+dir+"/cs\n")
+
+	d := parole.New()
+	j, err := parole.OpenJournal(dir+"/journal", d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out strings.Builder
+	e := New(d, strings.NewReader("i aa\ncs "+dir+"/cs\np\nu\np\ny\ni dd\n"), &out)
+	e.SetJournal(j, dir+"/snapshot")
+	if _, err := e.Run(); err != nil {
+		t.Fatal(err)
+	}
+	j.Close()
+	if atteso := "[\nbb\ncc\n]\n[\naa\n]\n"; out.String() != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out.String(), atteso)
+	}
+
+	// Il journal non dipende dal file dello snapshot caricato
+	os.Remove(dir + "/cs")
+
+	ripristinato := parole.New()
+	if _, err := parole.OpenJournal(dir+"/journal", ripristinato); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(ripristinato.Words(), " "); got != "bb cc dd" {
+		t.Errorf("parole ripristinate %q", got)
+	}
+}
+
+func TestAnnulla(t *testing.T) {
+	_, out := sessione(t, "i aa\ni bb\ne aa\nc\nu\np\nu\nu\np\ny\ny\np\ny\ny\n")
+	atteso := "[\nbb\n]\n[\naa\n]\n[\nbb\n]\nNessuna modifica da ripristinare\n"
+	if out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+
+	// Lo svuotamento di un dizionario vuoto non consuma un passo della storia
+	_, out = sessione(t, "i aa\nc\nc\nu\np\n")
+	if atteso := "[\naa\n]\n"; out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestDizionari(t *testing.T) {
+	input := "i aa\n" +
+		"dc principale copia\n" +
+		"dn glossario\n" +
+		"ds glossario\n" +
+		"i bb\n" +
+		"p\n" +
+		"ds copia\n" +
+		"i cc\n" +
+		"p\n" +
+		"dl\n" +
+		"de copia\n" +
+		"ds principale\n" +
+		"p\n" +
+		"de copia\n" +
+		"dl\n" +
+		"ds copia\n"
+	atteso := "[\nbb\n]\n" +
+		"[\naa\ncc\n]\n" +
+		"[\ncopia *\nglossario\nprincipale\n]\n" +
+		"Impossibile eliminare il dizionario copia\n" +
+		"[\naa\n]\n" +
+		"[\nglossario\nprincipale *\n]\n" +
+		"Dizionario inesistente: copia\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestOperazioniInsiemistiche(t *testing.T) {
+	input := "i aa\ni bb\ndn b\nds b\ni bb\ni cc\n" +
+		"dd principale b solo\ndu principale b tutte\ndi principale b comuni\n" +
+		"ds solo\np\nds tutte\np\nds comuni\np\ndu solo b comuni\n"
+	atteso := "[\naa\n]\n[\naa\nbb\ncc\n]\n[\nbb\n]\nDizionario già esistente: comuni\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestOpzioniCatena(t *testing.T) {
+	input := "i aa\ni aaa\ni aba\ni bba\n" +
+		"c aa bba algo=bfs stat\nc aa bba algo=bid\nc aa bba algo=dfs\nc aa bb stat\n"
+	atteso := "(\naa\naba\nbba\n)\nespansi: 3\n" +
+		"(\naa\naba\nbba\n)\n" +
+		"Formato errato per il comando c\n" +
+		"non esiste\nespansi: 0\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestCatenaAStar(t *testing.T) {
+	input := "i aaa\ni baa\ni bba\ni bbb\ni caa\ni aca\ni aac\ni cba\n" +
+		"c aaa bbb algo=astar cfr\n"
+	atteso := "(\naaa\nbaa\nbba\nbbb\n)\nespansi: 3\nespansi bfs: 6\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestTutteLeCatene(t *testing.T) {
+	input := "i aa\ni ab\ni ba\ni bb\n" +
+		"ct aa bb\nct aa bb 1\ncn aa bb\ncn aa bb 1\nct aa cc\ncn aa bb x\n"
+	atteso := "(\naa\nab\nbb\n)\n(\naa\nba\nbb\n)\n" +
+		"(\naa\nab\nbb\n)\n" +
+		"2\n1\nnon esiste\nFormato errato per il comando cn\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestKCatene(t *testing.T) {
+	input := "i aa\ni ab\ni bb\ni ba\ni cb\n" +
+		"ck aa bb 3\nck aa bb\n"
+	atteso := "(\naa\nab\nbb\n)\n(\naa\nba\nbb\n)\n(\naa\nab\ncb\nbb\n)\n" +
+		"Formato errato per il comando ck\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestVincoliCatena(t *testing.T) {
+	input := "i aa\ni ab\ni bb\ni ba\ni ca\ni cb\n" +
+		"c aa bb -ab -ba\nc aa bb +ba +ab\nc aa bb +ca +ab -cb\nc aa bb -ab -ba -cb\n"
+	atteso := "(\naa\nca\ncb\nbb\n)\n" +
+		"(\naa\nba\nca\ncb\nab\nbb\n)\n" +
+		"non esiste\n" +
+		"non esiste\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestPassoCatena(t *testing.T) {
+	input := "i abc\ni cab\ni acb\ni bac\n" +
+		"c abc cab\nc abc cab passo=anagr\nc abc cab passo=trasp\nc abc cab passo=dist2\nc abc cab passo=xyz\n"
+	atteso := "non esiste\n" +
+		"(\nabc\ncab\n)\n" +
+		"(\nabc\nacb\ncab\n)\n" +
+		"(\nabc\ncab\n)\n" +
+		"Formato errato per il comando c\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestCatenaPesata(t *testing.T) {
+	input := "i aa\ni ab\ni bb\ni aab\ni abb\n" +
+		"cw aa bb\ncw aa bb sost=5\ncw aa bb b=10\ncw aa cc\ncw aa bb sost=-1\ncw aa bb xy=2\n"
+	atteso := "(\naa\nab\nbb\n)\ncosto: 2\n" +
+		"(\naa\naab\nab\nabb\nbb\n)\ncosto: 4\n" +
+		"(\naa\nab\nbb\n)\ncosto: 22\n" +
+		"non esiste\n" +
+		"Formato errato per il comando cw\n" +
+		"Formato errato per il comando cw\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestGruppi(t *testing.T) {
+	input := "i bba\ni aa\ni aba\ni aaa\ni cca\ni xy\ni xyz\n" +
+		"g aa\ng zz\ngn\ng\n"
+	atteso := "[\naa\naaa\naba\nbba\n]\n" +
+		"non esiste\n" +
+		"gruppi: 3\ndimensioni: 4 2 1\n" +
+		"Formato errato per il comando g\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestAnalisi(t *testing.T) {
+	input := "i bba\ni aa\ni aba\ni aaa\ni cca\ni xy\ni xyz\n" +
+		"gg aba\ngg zz\ngh 2\ngi\nge aa\ngd\ngh x\n"
+	atteso := "3\nnon esiste\n" +
+		"aba: 3\naa: 2\n" +
+		"[\ncca\n]\n" +
+		"2\n" +
+		"dimensione: 4, diametro: 2\n(\naa\naba\nbba\n)\n" +
+		"dimensione: 2, diametro: 1\n(\nxy\nxyz\n)\n" +
+		"dimensione: 1, diametro: 0\n(\ncca\n)\n" +
+		"Formato errato per il comando gh\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestSchemiCompatibili(t *testing.T) {
+	input := "i aC\ni Ab\ni AA\ni Ba\ni ab\n" +
+		"rs ab\nrs cc\nrs aB\n"
+	atteso := "ab:[\nAb\naC\n]\n" +
+		"cc:[\nAA\n]\n" +
+		"Parola/schema non valida\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestRelazioniSchemi(t *testing.T) {
+	input := "i ABBA\ni aBBa\ni CDDC\ni ABCD\ni Ab\ni Ba\n" +
+		"sg ABBA aBBa\nsg aBBa ABBA\nso Ab Ba\nso Ab aC\nsm\nsg AB\nso A1 B\n"
+	atteso := "sì\nno\nno\nsì\n" +
+		"[\nABCD\nAb\nBa\n]\n" +
+		"Formato errato per il comando sg\n" +
+		"Parola/schema non valida\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestRicercaIniettiva(t *testing.T) {
+	input := "i aa\ni ab\ni abba\ni bbbb\ni cbbc\n" +
+		"r AB\nr AB inj\nr AbbA inj\nr AB xyz\n"
+	atteso := "AB:[\naa\nab\n]\n" +
+		"AB:[\nab\n]\n" +
+		"AbbA:[\nabba\ncbbc\n]\n" +
+		"Formato errato per il comando r\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
+
+func TestModelli(t *testing.T) {
+	input := "i abba\ni otto\ni anna\ni hello\ni aa\ni ab\ni cd\ni ee\n" +
+		"pm 2\npm\nr XY inj\nr XYYX\npm 0\n"
+	atteso := "ABBA: 3\nAA: 2\n" +
+		"ABBA: 3\nAA: 2\nAB: 2\nABCCD: 1\n" +
+		"XY:[\nab\ncd\n]\n" +
+		"XYYX:[\nabba\nanna\notto\n]\n" +
+		"Formato errato per il comando pm\n"
+	if _, out := sessione(t, input); out != atteso {
+		t.Errorf("output:\n%s\natteso:\n%s", out, atteso)
+	}
+}
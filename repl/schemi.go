@@ -0,0 +1,40 @@
+package repl
+
+import (
+	"fmt"
+
+	"solution/parole"
+)
+
+// Esegue i comandi sulle relazioni tra schemi:
+//
+//	sg S T  stampa "sì" se lo schema S è più generale di T, "no" altrimenti
+//	so S T  stampa "sì" se esiste una parola compatibile con S e con T, "no" altrimenti
+//	sm      stampa l'insieme minimo di schemi del dizionario con le stesse parole compatibili
+func (e *Engine) relazioniSchemi(campi []string) Status {
+	if campi[0] == "sm" {
+		if len(campi) != 1 {
+			return e.formatoErrato("sm")
+		}
+		parole.WriteSet(e.out, e.d.MinimalSchemas())
+		return StatusContinue
+	}
+
+	if len(campi) != 3 {
+		return e.formatoErrato(campi[0])
+	}
+	if !e.d.IsValid(campi[1]) || !e.d.IsValid(campi[2]) {
+		fmt.Fprintln(e.out, "Parola/schema non valida")
+		return StatusError
+	}
+	relazione := e.d.Subsumes
+	if campi[0] == "so" {
+		relazione = e.d.Overlaps
+	}
+	if relazione(campi[1], campi[2]) {
+		fmt.Fprintln(e.out, "sì")
+	} else {
+		fmt.Fprintln(e.out, "no")
+	}
+	return StatusContinue
+}